@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"sort"
 	"strings"
@@ -49,6 +50,8 @@ type CheckoutRequest struct {
 	Amount          decimal.Decimal
 	MerchantOrderID string
 	CustomerName    string
+	// 支付类型, 为空时使用 Config.DefaultPayType
+	PayType PayType
 }
 
 func (req *CheckoutRequest) Validate() error {
@@ -78,7 +81,7 @@ func (req *CheckoutRequest) toRaw(config *Config) *rawCheckoutPayload {
 		Uid:        config.MerchantID,
 		UniqueCode: req.CustomerID,
 		Money:      req.Amount.StringFixed(0),
-		PayType:    config.DefaultPayType,
+		PayType:    req.payType(config),
 		OrderID:    req.MerchantOrderID,
 		PayerName:  req.CustomerName,
 	}
@@ -86,12 +89,36 @@ func (req *CheckoutRequest) toRaw(config *Config) *rawCheckoutPayload {
 	return raw
 }
 
+func (req *CheckoutRequest) payType(config *Config) PayType {
+	if req.PayType != "" {
+		return req.PayType
+	}
+	return config.DefaultPayType
+}
+
 type PayType = string
 
+// Exlink-法币对接文档(20231207) 中仅定义了银联一种支付类型,
+// Exlink 为商户额外开通的支付类型通过 Config.PayTypes 注册
 const (
 	PayTypeUnionPay PayType = "1" // 银联
 )
 
+// pay type ==> pay type name
+var payTypes = map[PayType]string{
+	PayTypeUnionPay: "银联",
+}
+
+// 返回副本, 修改不影响包内状态
+func GetSupportPayTypes() map[PayType]string {
+	return maps.Clone(payTypes)
+}
+
+func IsSupportedPayType(payType PayType) bool {
+	_, ok := payTypes[payType]
+	return ok
+}
+
 type rawCheckoutPayload struct {
 	// 商户UID,对应商户后台的“商户编码"
 	Uid string `json:"uid"`
@@ -14,10 +14,15 @@ var ErrInvalidSign = errors.New("invalid sign")
 
 type TradeStatus = string
 
+// 文档中仅定义了 1：成功，其它为失败
 const (
 	TradeStatusSuccess TradeStatus = "1" // 成功
 )
 
+func IsFailedTradeStatus(status TradeStatus) bool {
+	return status != TradeStatusSuccess
+}
+
 type rawCheckoutCallbackPayload struct {
 	ApiOrderNo  string      `json:"apiOrderNo"`  // 商户订单号
 	Money       string      `json:"money"`       // 订单金额
@@ -82,6 +87,11 @@ func (req *CheckoutCallbackRequest) Amount() decimal.Decimal {
 	return req.money
 }
 
+// 回调金额与下单金额不一致时返回 true
+func (req *CheckoutCallbackRequest) IsAmountMismatch(requested decimal.Decimal) bool {
+	return !req.money.Equal(requested)
+}
+
 func (req *CheckoutCallbackRequest) Currency() string {
 	return "CNY"
 }
@@ -109,6 +119,10 @@ func (req *CheckoutCallbackRequest) IsSuccess() bool {
 	return req.Status() == TradeStatusSuccess
 }
 
+func (req *CheckoutCallbackRequest) IsFailed() bool {
+	return IsFailedTradeStatus(req.Status())
+}
+
 type CheckoutCallbackReply struct {
 	Code    responseCode `json:"code"`    // 交易状态。1：成功，其它为失败
 	Message string       `json:"message"` // 结果说明
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 
 	httptransport "github.com/decode-ex/payment-sdk/internal/http_transport"
@@ -35,8 +36,18 @@ func (e Env) baseURL() string {
 type Config struct {
 	MerchantID     string
 	DefaultPayType PayType
-	PublicKey      string
-	PrivateKey     string
+	// Exlink 为商户额外开通的支付类型, pay type ==> pay type name
+	// 与文档定义的银联并列, 可用于 CheckoutRequest.PayType
+	PayTypes   map[PayType]string
+	PublicKey  string
+	PrivateKey string
+}
+
+func (conf *Config) isSupportedPayType(payType PayType) bool {
+	if _, ok := conf.PayTypes[payType]; ok {
+		return true
+	}
+	return IsSupportedPayType(payType)
 }
 
 type Client struct {
@@ -50,6 +61,8 @@ func NewClient(env Env, conf Config) (*Client, error) {
 		return nil, err
 	}
 
+	conf.PayTypes = maps.Clone(conf.PayTypes)
+
 	return &Client{
 		http: &http.Client{
 			Transport: transport,
@@ -66,10 +79,20 @@ func NewProdClient(conf Config) (*Client, error) {
 	return NewClient(EnvProd, conf)
 }
 
+// 当前客户端可用的支付类型, 包括文档定义的与 Config.PayTypes 注册的
+func (cli *Client) GetSupportPayTypes() map[PayType]string {
+	types := GetSupportPayTypes()
+	maps.Copy(types, cli.config.PayTypes)
+	return types
+}
+
 func (cli *Client) Checkout(ctx context.Context, req *CheckoutRequest) (*CheckoutReply, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
+	if !cli.config.isSupportedPayType(req.payType(cli.config)) {
+		return nil, ErrorInvalidData
+	}
 	raw := req.toRaw(cli.config)
 	reqBody, err := raw.GenerateSignedRequest(ctx, cli.config)
 	if err != nil {
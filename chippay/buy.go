@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
-	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -16,14 +14,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/decode-ex/payment-sdk/internal/asymsign"
 	"github.com/decode-ex/payment-sdk/internal/strings2"
 )
 
 type signer struct{}
 
-func (signer) Sign(privateKey *rsa.PrivateKey, data map[string]string) (string, error) {
-	hashed := sha256.Sum256(signer{}.encode(data))
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+func (signer) Sign(privateKey crypto.Signer, data map[string]string) (string, error) {
+	signature, err := asymsign.Sign(privateKey, crypto.SHA256, signer{}.encode(data))
 	if err != nil {
 		return "", err
 	}
@@ -31,12 +29,11 @@ func (signer) Sign(privateKey *rsa.PrivateKey, data map[string]string) (string,
 }
 
 func (signer) Verify(publicKey *rsa.PublicKey, data map[string]string, signature string) error {
-	hashed := sha256.Sum256(signer{}.encode(data))
 	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
 		return fmt.Errorf("failed to decode signature: %w", err)
 	}
-	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signatureBytes)
+	return asymsign.Verify(publicKey, crypto.SHA256, signer{}.encode(data), signatureBytes)
 }
 
 func (signer) encode(data map[string]string) []byte {
@@ -169,7 +166,9 @@ func (raw *rawBuyPayload) serializeToMap() map[string]string {
 	if raw.AreaCode != "" {
 		params["areaCode"] = raw.AreaCode
 	}
-	params["phone"] = raw.Phone
+	if raw.Phone != "" {
+		params["phone"] = raw.Phone
+	}
 	if raw.Email != "" {
 		params["email"] = raw.Email
 	}
@@ -208,7 +207,7 @@ func (raw *rawBuyPayload) serializeToMap() map[string]string {
 	return params
 }
 
-func (raw *rawBuyPayload) generateSign(priavateKey *rsa.PrivateKey) (string, error) {
+func (raw *rawBuyPayload) generateSign(priavateKey crypto.Signer) (string, error) {
 	raw.params = raw.serializeToMap()
 	return signer{}.Sign(priavateKey, raw.params)
 }
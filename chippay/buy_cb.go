@@ -60,9 +60,13 @@ func (payload *rawBuyCoinCallbackPayload) serializeToMap() map[string]string {
 	params["unitPrice"] = payload.UnitPrice
 	params["total"] = payload.Total
 	params["successAmount"] = payload.SuccessAmount
+	if payload.CancelReason != "" {
+		params["cancelReason"] = payload.CancelReason
+	}
 	return params
 }
 
+// 快捷买单与快捷卖单共用同一个异步通知地址, 通过 OrderType 区分
 type BuyCoinCallbackRequest struct {
 	data *rawBuyCoinCallbackPayload
 
@@ -99,15 +103,44 @@ func (req *BuyCoinCallbackRequest) IsSuccess() bool {
 	return req.data.TradeStatus == TradeStatusSuccess
 }
 
+func (req *BuyCoinCallbackRequest) OrderType() OrderType {
+	return req.data.OrderType
+}
+
+func (req *BuyCoinCallbackRequest) IsSellOrder() bool {
+	return req.data.OrderType == OrderTypeSell
+}
+
+// 快捷批量卖单生成失败
+func (req *BuyCoinCallbackRequest) IsBatchFailed() bool {
+	return req.data.TradeStatus == TradeStatusBatchFailed
+}
+
+// 卖单取消原因, 仅当 orderType=2, tradeStatus=0 时返回
+func (req *BuyCoinCallbackRequest) CancelReason() string {
+	return req.data.CancelReason
+}
+
+type SellCoinCallbackRequest = BuyCoinCallbackRequest
+
+func ParseSellCoinCallbackRequest(req *http.Request) (*SellCoinCallbackRequest, error) {
+	return ParseBuyCoinCallbackRequest(req)
+}
+
 func ParseBuyCoinCallbackRequest(ree *http.Request) (*BuyCoinCallbackRequest, error) {
 	payload := &rawBuyCoinCallbackPayload{}
 	if err := json.NewDecoder(ree.Body).Decode(payload); err != nil {
 		return nil, fmt.Errorf("failed to decode request body: %w", err)
 	}
 
-	total, err := decimal.NewFromString(payload.Total)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse total amount: %w", err)
+	// 卖单失败或批量卖单生成失败时可能不返回金额
+	total := decimal.Zero
+	if payload.Total != "" {
+		var err error
+		total, err = decimal.NewFromString(payload.Total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse total amount: %w", err)
+		}
 	}
 
 	return &BuyCoinCallbackRequest{
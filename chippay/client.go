@@ -2,17 +2,19 @@ package chippay
 
 import (
 	"context"
+	"crypto"
 	"crypto/rsa"
-	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/decode-ex/payment-sdk/internal/asymsign"
 	httptransport "github.com/decode-ex/payment-sdk/internal/http_transport"
+	"github.com/decode-ex/payment-sdk/internal/rsakey"
 	"github.com/shopspring/decimal"
 )
 
@@ -41,13 +43,24 @@ func (e Env) baseURL() string {
 
 type Config struct {
 	MerchantID string
+	// 支持 PEM (PKCS#1/PKCS#8/PKIX) 或 base64 编码的 DER
 	PublicKey  string
 	PrivateKey string
 
+	// 私钥托管在外部进程时使用, 设置后忽略 PrivateKey/PrivateKeyFile/PrivateKeyReader
+	// 公钥必须为 RSA, ChipPay 仅支持 SHA256withRSA
+	Signer crypto.Signer
+
+	// PublicKey/PrivateKey 为空时, 依次从文件或 io.Reader 读取
+	PublicKeyFile    string
+	PrivateKeyFile   string
+	PublicKeyReader  io.Reader
+	PrivateKeyReader io.Reader
+
 	CallbackURL string
 	RedirectURL string
 
-	privateKey *rsa.PrivateKey
+	privateKey crypto.Signer
 	publicKey  *rsa.PublicKey
 }
 
@@ -62,30 +75,13 @@ func NewClient(env Env, config Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to create transport: %w", err)
 	}
 
-	priKeyBytes, err := base64.StdEncoding.DecodeString(config.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode private key: %w", err)
-	}
-	priKey, err := x509.ParsePKCS8PrivateKey(priKeyBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
-	}
-	rsaPriKey, ok := priKey.(*rsa.PrivateKey)
-	if !ok {
-		return nil, errors.New("invalid private key type")
-	}
-
-	pubKeyBytes, err := base64.StdEncoding.DecodeString(config.PublicKey)
+	priKey, err := config.loadPrivateKey()
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode public key: %w", err)
+		return nil, err
 	}
-	pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+	rsaPubKey, err := config.loadPublicKey()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse public key: %w", err)
-	}
-	rsaPubKey, ok := pubKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, errors.New("invalid public key type")
+		return nil, err
 	}
 
 	return &Client{
@@ -93,17 +89,67 @@ func NewClient(env Env, config Config) (*Client, error) {
 			Transport: transport,
 		},
 		config: &Config{
-			MerchantID:  config.MerchantID,
-			PublicKey:   config.PublicKey,
-			PrivateKey:  config.PrivateKey,
-			CallbackURL: config.CallbackURL,
-			RedirectURL: config.RedirectURL,
-			privateKey:  rsaPriKey,
-			publicKey:   rsaPubKey,
+			MerchantID:     config.MerchantID,
+			PublicKey:      config.PublicKey,
+			PrivateKey:     config.PrivateKey,
+			PublicKeyFile:  config.PublicKeyFile,
+			PrivateKeyFile: config.PrivateKeyFile,
+			CallbackURL:    config.CallbackURL,
+			RedirectURL:    config.RedirectURL,
+			Signer:         config.Signer,
+			privateKey:     priKey,
+			publicKey:      rsaPubKey,
 		},
 	}, nil
 }
 
+func (config *Config) loadPrivateKey() (crypto.Signer, error) {
+	var (
+		key *rsa.PrivateKey
+		err error
+	)
+	switch {
+	case config.Signer != nil:
+		if _, err := asymsign.RSAPublicKey(config.Signer); err != nil {
+			return nil, fmt.Errorf("invalid signer: %w", err)
+		}
+		return config.Signer, nil
+	case config.PrivateKey != "":
+		key, err = rsakey.ParsePrivateKey([]byte(config.PrivateKey))
+	case config.PrivateKeyFile != "":
+		key, err = rsakey.LoadPrivateKeyFile(config.PrivateKeyFile)
+	case config.PrivateKeyReader != nil:
+		key, err = rsakey.ReadPrivateKey(config.PrivateKeyReader)
+	default:
+		return nil, errors.New("private key is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key: %w", err)
+	}
+	return key, nil
+}
+
+func (config *Config) loadPublicKey() (*rsa.PublicKey, error) {
+	var (
+		key *rsa.PublicKey
+		err error
+	)
+	switch {
+	case config.PublicKey != "":
+		key, err = rsakey.ParsePublicKey([]byte(config.PublicKey))
+	case config.PublicKeyFile != "":
+		key, err = rsakey.LoadPublicKeyFile(config.PublicKeyFile)
+	case config.PublicKeyReader != nil:
+		key, err = rsakey.ReadPublicKey(config.PublicKeyReader)
+	default:
+		return nil, errors.New("public key is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+	return key, nil
+}
+
 func NewDevClient(conf Config) (*Client, error) {
 	return NewClient(EnvDev, conf)
 }
@@ -115,26 +161,52 @@ func NewProdClient(conf Config) (*Client, error) {
 type BuyCoinRequest struct {
 	MerchantOrderID string
 
-	Amount   decimal.Decimal
-	Currency string
+	// 用户付款的法币总金额, 快捷买单只能传整数
+	// Amount 与 CoinAmount 二选一
+	Amount decimal.Decimal
+	// 买入的USDT数量, 精度最多至小数点后4位
+	// 换算后的法币金额若不为整数，将无条件进位为整数显示于收银台
+	CoinAmount decimal.Decimal
+	Currency   string
+
+	// 用户验证级别, 为空时默认为 "2"
+	KYCLevel string
 
 	CustomerAreaCode string
 	CustomerPhone    string
-	CustomerName     string
+	// 仅当币种为vnd时支持, phone或者email需择一传输
+	CustomerEmail string
+	CustomerName  string
+
+	IDCardType IDCardType
+	IDCardNum  string
+
+	// 为空时由ChipPay决定, cny支持支付宝、银行卡, vnd支持MOMO、银行卡
+	PayChannel OrderPayChannel
+	// 客户自定义单价, 最多四位小数
+	DisplayUnitPrice decimal.Decimal
 }
 
-func (raw *BuyCoinRequest) Validate() error {
-	if raw.MerchantOrderID == "" {
-		return ErrInvalidMerchantOrderID
-	}
+const defaultKYCLevel = "2"
 
-	if raw.Amount.LessThanOrEqual(decimal.Zero) {
-		return errors.New("amount must be greater than zero")
+// payCoinSign ==> 快捷买单支持的付款方式
+var buyPayChannels = map[PayCoinSign][]OrderPayChannel{
+	PayCoinSignCNY: {OrderPayChannel_Alipay, OrderPayChannel_BankCard},
+	PayCoinSignVND: {OrderPayChannel_MOMO, OrderPayChannel_BankCard},
+}
+
+func IsBuyPayChannelSupported(currency string, channel OrderPayChannel) bool {
+	for _, allow := range buyPayChannels[strings.ToLower(currency)] {
+		if allow == channel {
+			return true
+		}
 	}
+	return false
+}
 
-	amount := raw.Amount.Truncate(0)
-	if !amount.Equal(raw.Amount) {
-		return ErrInvalidAmount
+func (raw *BuyCoinRequest) Validate() error {
+	if raw.MerchantOrderID == "" {
+		return ErrInvalidMerchantOrderID
 	}
 
 	if raw.Currency == "" {
@@ -152,32 +224,86 @@ func (raw *BuyCoinRequest) Validate() error {
 		return ErrInvalidCurrency
 	}
 
+	switch {
+	case raw.Amount.IsZero() && raw.CoinAmount.IsZero():
+		return errors.New("amount must be greater than zero")
+	case !raw.Amount.IsZero() && !raw.CoinAmount.IsZero():
+		return errors.New("amount and coin amount are mutually exclusive")
+	case !raw.Amount.IsZero():
+		if raw.Amount.IsNegative() {
+			return errors.New("amount must be greater than zero")
+		}
+		amount := raw.Amount.Truncate(0)
+		if !amount.Equal(raw.Amount) {
+			return ErrInvalidAmount
+		}
+	default:
+		if raw.CoinAmount.IsNegative() {
+			return errors.New("coin amount must be greater than zero")
+		}
+		if !raw.CoinAmount.Truncate(4).Equal(raw.CoinAmount) {
+			return ErrInvalidCoinAmount
+		}
+	}
+
+	isVND := strings.EqualFold(raw.Currency, PayCoinSignVND)
+	if raw.CustomerEmail != "" && !isVND {
+		return ErrInvalidCustomerEmail
+	}
 	if raw.CustomerPhone == "" {
-		return ErrInvalidCustomerPhone
+		if !isVND {
+			return ErrInvalidCustomerPhone
+		}
+		if raw.CustomerEmail == "" {
+			return ErrInvalidCustomerPhone
+		}
+	}
+	if err := ValidateUserName(raw.Currency, raw.CustomerName); err != nil {
+		return err
+	}
+
+	if raw.PayChannel != 0 && !IsBuyPayChannelSupported(raw.Currency, raw.PayChannel) {
+		return ErrInvalidPayChannel
 	}
-	if raw.CustomerName == "" {
-		return ErrInvalidCustomerName
+
+	if raw.DisplayUnitPrice.IsNegative() || !raw.DisplayUnitPrice.Truncate(4).Equal(raw.DisplayUnitPrice) {
+		return ErrInvalidUnitPrice
 	}
-	return nil
 
+	return validateIDCard(raw.Currency, raw.IDCardType, raw.IDCardNum)
 }
 
 func (req *BuyCoinRequest) toRaw(conf *Config) *rawBuyPayload {
-	return &rawBuyPayload{
+	raw := &rawBuyPayload{
 		CompanyID:       conf.MerchantID,
-		KYCLevel:        "2",
-		UserName:        req.CustomerName,
+		KYCLevel:        req.KYCLevel,
+		UserName:        NormalizeUserName(req.Currency, req.CustomerName),
 		AreaCode:        req.CustomerAreaCode,
 		Phone:           req.CustomerPhone,
+		Email:           req.CustomerEmail,
 		OrderType:       OrderTypeBuy,
+		IDCardType:      req.IDCardType,
+		IDCardNum:       req.IDCardNum,
 		CompanyOrderNum: req.MerchantOrderID,
 		CoinSign:        CoinSignUSDT,
 		PayCoinSign:     strings.ToLower(req.Currency),
-		Total:           req.Amount.StringFixed(0),
+		OrderPayChannel: req.PayChannel,
 		OrderTime:       time.Now(),
 		SyncURL:         conf.RedirectURL,
 		AsyncUrl:        conf.CallbackURL,
 	}
+	if raw.KYCLevel == "" {
+		raw.KYCLevel = defaultKYCLevel
+	}
+	if !req.Amount.IsZero() {
+		raw.Total = req.Amount.StringFixed(0)
+	} else {
+		raw.CoinAmount = req.CoinAmount.String()
+	}
+	if !req.DisplayUnitPrice.IsZero() {
+		raw.DisplayUnitPrice = req.DisplayUnitPrice.String()
+	}
+	return raw
 }
 
 type BuyCoinReply struct {
@@ -225,3 +351,31 @@ func (c *Client) BuyCoin(ctx context.Context, req *BuyCoinRequest) (*BuyCoinRepl
 
 	return BuyCoinReply{}.fromRaw(&rawReply)
 }
+
+func (c *Client) SellCoin(ctx context.Context, req *SellCoinRequest) (*SellCoinReply, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	raw := req.toRaw(c.config)
+	sellReq, err := raw.GenerateSignedRequest(ctx, c.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signed request: %w", err)
+	}
+	resp, err := c.http.Do(sellReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	rawReply := raw.Reply()
+	if err := json.NewDecoder(resp.Body).Decode(&rawReply); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return BuyCoinReply{}.fromRaw(&rawReply)
+}
@@ -5,7 +5,14 @@ import "errors"
 var (
 	ErrInvalidMerchantOrderID = errors.New("invalid merchant order ID")
 	ErrInvalidAmount          = errors.New("invalid amount")
+	ErrInvalidCoinAmount      = errors.New("invalid coin amount")
 	ErrInvalidCurrency        = errors.New("invalid currency")
 	ErrInvalidCustomerPhone   = errors.New("invalid customer phone")
+	ErrInvalidCustomerEmail   = errors.New("invalid customer email")
 	ErrInvalidCustomerName    = errors.New("invalid customer name")
+	ErrInvalidPayChannel      = errors.New("invalid pay channel")
+	ErrInvalidUnitPrice       = errors.New("invalid display unit price")
+	ErrInvalidBankCardNo      = errors.New("invalid bank card number")
+	ErrInvalidBankName        = errors.New("invalid bank name")
+	ErrInvalidIDCard          = errors.New("invalid id card")
 )
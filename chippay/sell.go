@@ -0,0 +1,134 @@
+package chippay
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	bankCardNoPattern     = regexp.MustCompile(`^\d{6,30}$`)
+	cnIDCardNumPattern    = regexp.MustCompile(`^\d{17}[\dXx]$`)
+	otherIDCardNumPattern = regexp.MustCompile(`^[A-Za-z0-9]{5,32}$`)
+)
+
+// 快捷卖单: 用户卖出 USDT, ChipPay 将法币打款至用户银行卡
+type SellCoinRequest struct {
+	MerchantOrderID string
+
+	// 用户收款的法币总金额, 快捷卖单不限(不要求整数)
+	Amount   decimal.Decimal
+	Currency string
+
+	CustomerAreaCode string
+	CustomerPhone    string
+	CustomerName     string
+
+	IDCardType IDCardType
+	IDCardNum  string
+
+	// 收款银行卡号
+	BankCardNo string
+	// 开户银行, 当币种为vnd时需准确填入ChipPay支持的银行名称
+	BankName string
+	// 开户支行
+	BankBranch string
+}
+
+func (req *SellCoinRequest) Validate() error {
+	if req.MerchantOrderID == "" {
+		return ErrInvalidMerchantOrderID
+	}
+
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return errors.New("amount must be greater than zero")
+	}
+
+	if req.Currency == "" {
+		return ErrInvalidCurrency
+	}
+	currencyAllow := false
+	for _, allow := range []string{"CNY", "VND"} {
+		if strings.EqualFold(req.Currency, allow) {
+			currencyAllow = true
+			break
+		}
+	}
+	if !currencyAllow {
+		return ErrInvalidCurrency
+	}
+
+	if req.CustomerPhone == "" {
+		return ErrInvalidCustomerPhone
+	}
+	if err := ValidateUserName(req.Currency, req.CustomerName); err != nil {
+		return err
+	}
+
+	if !bankCardNoPattern.MatchString(req.BankCardNo) {
+		return ErrInvalidBankCardNo
+	}
+	if strings.TrimSpace(req.BankName) == "" {
+		return ErrInvalidBankName
+	}
+
+	return validateIDCard(req.Currency, req.IDCardType, req.IDCardNum)
+}
+
+// 证件为选填, 填写时证件类型与号码需同时提供
+// 身份证号仅对 CNY 按 18 位居民身份证校验, 其他币种(如越南 CCCD)只要求非空
+func validateIDCard(currency string, typ IDCardType, num string) error {
+	if typ == 0 && num == "" {
+		return nil
+	}
+	switch typ {
+	case IDCardTypeID:
+		if !strings.EqualFold(currency, "CNY") {
+			if strings.TrimSpace(num) == "" {
+				return ErrInvalidIDCard
+			}
+			break
+		}
+		if !cnIDCardNumPattern.MatchString(num) {
+			return ErrInvalidIDCard
+		}
+	case IDCardTypePassport, IDCardTypeOther:
+		if !otherIDCardNumPattern.MatchString(num) {
+			return ErrInvalidIDCard
+		}
+	default:
+		return ErrInvalidIDCard
+	}
+	return nil
+}
+
+func (req *SellCoinRequest) toRaw(conf *Config) *rawBuyPayload {
+	return &rawBuyPayload{
+		CompanyID:       conf.MerchantID,
+		KYCLevel:        defaultKYCLevel,
+		UserName:        NormalizeUserName(req.Currency, req.CustomerName),
+		AreaCode:        req.CustomerAreaCode,
+		Phone:           req.CustomerPhone,
+		OrderType:       OrderTypeSell,
+		IDCardType:      req.IDCardType,
+		IDCardNum:       req.IDCardNum,
+		PayCardNo:       req.BankCardNo,
+		PayCardBank:     req.BankName,
+		PayCardBranch:   req.BankBranch,
+		CompanyOrderNum: req.MerchantOrderID,
+		CoinSign:        CoinSignUSDT,
+		PayCoinSign:     strings.ToLower(req.Currency),
+		Total:           req.Amount.String(),
+		// 卖单仅支持银行卡方式
+		OrderPayChannel: OrderPayChannel_BankCard,
+		OrderTime:       time.Now(),
+		SyncURL:         conf.RedirectURL,
+		AsyncUrl:        conf.CallbackURL,
+	}
+}
+
+// 快捷卖单与快捷买单共用下单接口, 返回结构一致
+type SellCoinReply = BuyCoinReply
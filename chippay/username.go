@@ -0,0 +1,67 @@
+package chippay
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+var (
+	// cny: ([\s·一-龥]{2,15})|([\s·A-Za-z]{2,35})
+	cnyChineseUserNamePattern = regexp.MustCompile(`^[\s·\x{4e00}-\x{9fa5}]{2,15}$`)
+	cnyLatinUserNamePattern   = regexp.MustCompile(`^[\s·A-Za-z]{2,35}$`)
+	// vnd: 包含以下特殊字符或数字则会报错
+	vndForbiddenUserNamePattern = regexp.MustCompile("[`~!@#$%^&()+=|{}':;,\\[\\].<>?！￥…（）—【】‘；：”“’。，、？\\d]")
+)
+
+// 各类间隔号统一为 U+00B7
+var middleDotReplacer = strings.NewReplacer(
+	"・", "·", // 片假名中点
+	"･", "·", // 半角片假名中点
+	"•", "·", // 项目符号
+	"‧", "·", // 连字点
+	"⋅", "·", // 点运算符
+	"·", "·", // 希腊文上点
+)
+
+// NormalizeUserName 将全角字符转换为半角, 统一间隔号, 并合并多余空白
+// cny 中文姓名中间不留空格
+func NormalizeUserName(currency string, name string) string {
+	name = width.Narrow.String(name)
+	name = middleDotReplacer.Replace(name)
+	fields := strings.FieldsFunc(name, unicode.IsSpace)
+	if strings.EqualFold(currency, PayCoinSignCNY) && strings.IndexFunc(name, isHan) >= 0 {
+		return strings.Join(fields, "")
+	}
+	return strings.Join(fields, " ")
+}
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// ValidateUserName 按 payCoinSign 校验真实姓名, 校验前会先进行 NormalizeUserName
+func ValidateUserName(currency string, name string) error {
+	name = NormalizeUserName(currency, name)
+	if name == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidCustomerName)
+	}
+
+	switch strings.ToLower(currency) {
+	case PayCoinSignCNY:
+		if cnyChineseUserNamePattern.MatchString(name) || cnyLatinUserNamePattern.MatchString(name) {
+			return nil
+		}
+		return fmt.Errorf("%w: cny name must be 2-15 chinese or 2-35 latin characters", ErrInvalidCustomerName)
+	case PayCoinSignVND:
+		if ch := vndForbiddenUserNamePattern.FindString(name); ch != "" {
+			return fmt.Errorf("%w: vnd name contains forbidden character %q", ErrInvalidCustomerName, ch)
+		}
+		return nil
+	default:
+		return ErrInvalidCurrency
+	}
+}
@@ -4,5 +4,6 @@ go 1.23.2
 
 require (
 	github.com/shopspring/decimal v1.4.0
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	golang.org/x/text v0.23.0
 )
@@ -0,0 +1,269 @@
+package help2pay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+type BankType = string
+
+const (
+	BankTypeOnlineBanking  BankType = "online_banking"
+	BankTypeQR             BankType = "qr"
+	BankTypeEWallet        BankType = "e_wallet"
+	BankTypeLocalTransfer  BankType = "local_transfer" // *LBT, *DUITNOW 及 Semi/Manual Transfer
+	BankTypeVirtualAccount BankType = "virtual_account"
+	BankTypeCrypto         BankType = "crypto"
+)
+
+var eWalletBanks = map[string]struct{}{
+	"VIETQRMOMO":    {},
+	"VIETQRZALO":    {},
+	"VIETQRVIETTEL": {},
+	"GCASHQRPH":     {},
+	"DANAQRIS":      {},
+	"GOPAYQRIS":     {},
+	"LINKAJAQRIS":   {},
+	"OVOQRIS":       {},
+	"SHOPEEQRIS":    {},
+	"UPI":           {},
+	"HDFCUPI":       {},
+}
+
+var localTransferBanks = map[string]struct{}{
+	"TSTB": {}, // Thai Semi Transfer Bank
+	"VSTB": {}, // VND Semi Transfer Bank
+	"PSTB": {}, // PHP Semi Transfer Bank
+	"IMTB": {}, // IDR Manual Transfer Bank
+}
+
+func classifyBank(code string) BankType {
+	if IsCryptoBank(code) {
+		return BankTypeCrypto
+	}
+	if _, ok := eWalletBanks[code]; ok {
+		return BankTypeEWallet
+	}
+	if _, ok := localTransferBanks[code]; ok {
+		return BankTypeLocalTransfer
+	}
+	switch {
+	case strings.HasSuffix(code, "LBT"), strings.HasSuffix(code, "DUITNOW"):
+		return BankTypeLocalTransfer
+	case strings.HasSuffix(code, "VA"), code == "ISTB":
+		return BankTypeVirtualAccount
+	case strings.Contains(code, "QR"), code == "PPTP":
+		return BankTypeQR
+	default:
+		return BankTypeOnlineBanking
+	}
+}
+
+// BankInfo 记录某币种下一个存款银行的展示信息与限额
+type BankInfo struct {
+	Code     string       `json:"code"`
+	Currency CurrencyCode `json:"currency"`
+	Name     string       `json:"name"`
+	Type     BankType     `json:"type"`
+	// 单笔最小/最大金额, 为零表示不限制
+	MinAmount decimal.Decimal `json:"min_amount"`
+	MaxAmount decimal.Decimal `json:"max_amount"`
+	// 前端用于查找银行图标
+	LogoKey string `json:"logo_key"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (info *BankInfo) CheckAmount(amount decimal.Decimal) error {
+	if !info.MinAmount.IsZero() && amount.LessThan(info.MinAmount) {
+		return fmt.Errorf("%w: less than %s minimum %s", ErrInvalidAmount, info.Code, info.MinAmount)
+	}
+	if !info.MaxAmount.IsZero() && amount.GreaterThan(info.MaxAmount) {
+		return fmt.Errorf("%w: greater than %s maximum %s", ErrInvalidAmount, info.Code, info.MaxAmount)
+	}
+	return nil
+}
+
+// BankOverride 为 JSON 覆盖配置中的一项, 未填写的字段保持原值
+// 覆盖配置中出现的新银行代码会被加入目录, 此时 name 与 type 必填
+type BankOverride struct {
+	Code      string           `json:"code"`
+	Currency  CurrencyCode     `json:"currency"`
+	Name      *string          `json:"name,omitempty"`
+	Type      *BankType        `json:"type,omitempty"`
+	MinAmount *decimal.Decimal `json:"min_amount,omitempty"`
+	MaxAmount *decimal.Decimal `json:"max_amount,omitempty"`
+	LogoKey   *string          `json:"logo_key,omitempty"`
+	Enabled   *bool            `json:"enabled,omitempty"`
+}
+
+type BankCatalog struct {
+	mu    sync.RWMutex
+	banks map[CurrencyCode]map[string]*BankInfo
+}
+
+// NewBankCatalog 返回基于内置银行列表的目录, 所有银行默认启用且不限额
+// 文档未给出限额, 实际限额以 Help2Pay 为商户开通的配置为准, 通过覆盖配置设置
+func NewBankCatalog() *BankCatalog {
+	catalog := &BankCatalog{
+		banks: make(map[CurrencyCode]map[string]*BankInfo, len(depositBanks)),
+	}
+	for currency, banks := range depositBanks {
+		catalog.banks[currency] = make(map[string]*BankInfo, len(banks))
+		for code := range banks {
+			catalog.banks[currency][code] = &BankInfo{
+				Code:     code,
+				Currency: currency,
+				Name:     bankCodes[code],
+				Type:     classifyBank(code),
+				LogoKey:  strings.ToLower(code),
+				Enabled:  true,
+			}
+		}
+	}
+	return catalog
+}
+
+// Get 返回银行信息的副本
+func (c *BankCatalog) Get(currency CurrencyCode, code string) (BankInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	info, ok := c.banks[currency][code]
+	if !ok {
+		return BankInfo{}, false
+	}
+	return *info, true
+}
+
+// List 返回币种下的银行, 按代码排序, enabledOnly 为 true 时跳过已停用的银行
+func (c *BankCatalog) List(currency CurrencyCode, enabledOnly bool) []BankInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	list := make([]BankInfo, 0, len(c.banks[currency]))
+	for _, info := range c.banks[currency] {
+		if enabledOnly && !info.Enabled {
+			continue
+		}
+		list = append(list, *info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Code < list[j].Code
+	})
+	return list
+}
+
+func (c *BankCatalog) Currencies() []CurrencyCode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	list := make([]CurrencyCode, 0, len(c.banks))
+	for currency := range c.banks {
+		list = append(list, currency)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func (c *BankCatalog) IsEnabled(currency CurrencyCode, code string) bool {
+	info, ok := c.Get(currency, code)
+	return ok && info.Enabled
+}
+
+// Apply 以内置默认目录为基础应用覆盖配置, 替换之前应用的所有覆盖
+// 覆盖配置中删除的项会恢复默认值; 任一项校验失败时不修改目录
+func (c *BankCatalog) Apply(overrides []BankOverride) error {
+	next := NewBankCatalog().banks
+
+	for i, o := range overrides {
+		if o.Code == "" || o.Currency == "" {
+			return fmt.Errorf("override #%d: code and currency are required", i)
+		}
+		info, ok := next[o.Currency][o.Code]
+		if !ok {
+			if o.Name == nil || o.Type == nil {
+				return fmt.Errorf("override #%d: new bank %s/%s requires name and type", i, o.Currency, o.Code)
+			}
+			info = &BankInfo{
+				Code:     o.Code,
+				Currency: o.Currency,
+				LogoKey:  strings.ToLower(o.Code),
+				Enabled:  true,
+			}
+			if next[o.Currency] == nil {
+				next[o.Currency] = make(map[string]*BankInfo)
+			}
+			next[o.Currency][o.Code] = info
+		}
+		if o.Name != nil {
+			info.Name = *o.Name
+		}
+		if o.Type != nil {
+			info.Type = *o.Type
+		}
+		if o.MinAmount != nil {
+			info.MinAmount = *o.MinAmount
+		}
+		if o.MaxAmount != nil {
+			info.MaxAmount = *o.MaxAmount
+		}
+		if o.LogoKey != nil {
+			info.LogoKey = *o.LogoKey
+		}
+		if o.Enabled != nil {
+			info.Enabled = *o.Enabled
+		}
+		if info.MinAmount.IsNegative() || info.MaxAmount.IsNegative() ||
+			(!info.MaxAmount.IsZero() && info.MaxAmount.LessThan(info.MinAmount)) {
+			return fmt.Errorf("override #%d: invalid amount range for %s/%s", i, o.Currency, o.Code)
+		}
+	}
+
+	c.mu.Lock()
+	c.banks = next
+	c.mu.Unlock()
+	return nil
+}
+
+// LoadOverrides 从 JSON 数组读取覆盖配置并应用
+func (c *BankCatalog) LoadOverrides(r io.Reader) error {
+	var overrides []BankOverride
+	if err := json.NewDecoder(r).Decode(&overrides); err != nil {
+		return fmt.Errorf("decode bank overrides: %w", err)
+	}
+	return c.Apply(overrides)
+}
+
+// LoadOverridesFile 从 JSON 文件读取覆盖配置并应用, 可在运行时重复调用以重新加载,
+// 每次加载都以默认目录为基础
+func (c *BankCatalog) LoadOverridesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open bank overrides: %w", err)
+	}
+	defer f.Close()
+	return c.LoadOverrides(f)
+}
+
+var defaultBankCatalog = NewBankCatalog()
+
+// DefaultBankCatalog 为存款校验使用的全局目录
+func DefaultBankCatalog() *BankCatalog {
+	return defaultBankCatalog
+}
+
+func checkDepositBank(currency CurrencyCode, code string, amount decimal.Decimal) error {
+	info, ok := defaultBankCatalog.Get(currency, code)
+	if !ok {
+		return errors.New("invalid bank or currency")
+	}
+	if !info.Enabled {
+		return fmt.Errorf("%w: %s is disabled", ErrUnsupportedBank, code)
+	}
+	return info.CheckAmount(amount)
+}
@@ -237,12 +237,26 @@ var bankCodes = map[string]string{
 	"PMTBLBT":       "Bank Permata Local Bank Transfer",
 }
 
+// crypto bank code ==> bank name
+// 文档未给出加密货币对应的 Currency 取值, 因此不按币种归类, 也不计入法币银行
+var cryptoBankCodes = map[string]string{
+	"TET-ETHE": "USDT - ERC20",
+	"TEX-TRON": "USDT - TRC20",
+}
+
+// crypto bank code ==> amount decimal places
+var cryptoBankDecimals = map[string]int32{
+	"TET-ETHE": 6,
+	"TEX-TRON": 2,
+}
+
 type Bank struct {
 	Code string
 	Name string
 }
 
 var fiat_support_banks = make(map[string]*Bank)
+var crypto_support_banks = make(map[string]*Bank)
 var currency_support_banks = make(map[CurrencyCode][]string)
 
 func init() {
@@ -253,6 +267,12 @@ func init() {
 		}
 		fiat_support_banks[code] = item
 	}
+	for code, name := range cryptoBankCodes {
+		crypto_support_banks[code] = &Bank{
+			Code: code,
+			Name: name,
+		}
+	}
 	for currency, banks := range depositBanks {
 		currency_support_banks[currency] = make([]string, 0, len(banks))
 		for code := range banks {
@@ -265,6 +285,10 @@ func GetFiatSupportBanks() map[string]*Bank {
 	return fiat_support_banks
 }
 
+func GetCryptoSupportBanks() map[string]*Bank {
+	return crypto_support_banks
+}
+
 func GetCurrencySupportBanks() map[CurrencyCode][]string {
 	return currency_support_banks
 }
@@ -274,11 +298,21 @@ func IsFiatSupportBank(code string) bool {
 	return ok
 }
 
+// 已在 DefaultBankCatalog 中停用的银行视为不支持
 func IsCurrencySupportBank(currency CurrencyCode, code string) bool {
-	banks, ok := depositBanks[currency]
-	if !ok {
-		return false
-	}
-	_, ok = banks[code]
+	return defaultBankCatalog.IsEnabled(currency, code)
+}
+
+func IsCryptoBank(code string) bool {
+	_, ok := crypto_support_banks[code]
 	return ok
 }
+
+// Fiat and cryptocurrency may have different decimal places
+// Fiat Numerical figures with 2 decimal places.
+func getAmountDecimals(bank string) int32 {
+	if places, ok := cryptoBankDecimals[bank]; ok {
+		return places
+	}
+	return 2
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -57,6 +58,26 @@ const (
 	ErrorCodeTransactionLimit ErrorCode = "TransactionLimit"
 )
 
+// user-facing messages for ErrorCode
+var errorCodeMessages = map[ErrorCode]string{
+	ErrorCodeAccountPassword:     "Incorrect bank account password.",
+	ErrorCodeAccountSetting:      "Unable to log in to the bank account, or the account setup is incomplete.",
+	ErrorCodeATMApproveRequired:  "ATM approval is required by your bank.",
+	ErrorCodeBlacklisted:         "This account is not allowed to make deposits.",
+	ErrorCodeInsufficientFund:    "Insufficient funds in the bank account.",
+	ErrorCodeInvalidTAC:          "Invalid TAC entered.",
+	ErrorCodeMaintenance:         "Your bank is under maintenance. Please try again later.",
+	ErrorCodeSession:             "The session has timed out.",
+	ErrorCodeTimeOutTAC:          "The TAC has expired.",
+	ErrorCodeTransactionDeclined: "The transaction was declined by your bank.",
+	ErrorCodeTransactionLimit:    "The bank account has reached its transaction limit.",
+}
+
+// ErrorCodeMessage returns a user-facing message for code, or an empty string if code is unknown.
+func ErrorCodeMessage(code ErrorCode) string {
+	return errorCodeMessages[code]
+}
+
 type rawDepositCallbackPayload struct {
 	// Merchant submitted during the transfer transaction.
 	Merchant string `form:"Merchant"`
@@ -73,7 +94,7 @@ type rawDepositCallbackPayload struct {
 	// Datetime submitted during the transfer transaction.
 	Datetime string `form:"Datetime"`
 	// Datetime for the transaction processed. In UTC time.
-	StatementDate string `protobuf:"bytes,8,opt,name=StatementDate,proto3" json:"StatementDate,omitempty"`
+	StatementDate string `form:"StatementDate"`
 	// Note submitted during the transfer transaction.
 	Note string `form:"Note"`
 	// EncrytedSign generated by Gateway for verification to prevent fraud.
@@ -185,6 +206,64 @@ func (req *DepositCallbackRequest) Status() StatusCode {
 	return req.raw.Status
 }
 
+func (req *DepositCallbackRequest) Language() LanguageCode {
+	return req.raw.Language
+}
+
+func (req *DepositCallbackRequest) Note() string {
+	return req.raw.Note
+}
+
+// 失败原因, 成功或未返回时为空
+func (req *DepositCallbackRequest) ErrorCode() ErrorCode {
+	return req.raw.ErrorCode
+}
+
+// 面向用户的失败原因描述, 未知错误码时返回原始错误码
+func (req *DepositCallbackRequest) FailureMessage() string {
+	if req.raw.ErrorCode == "" {
+		return ""
+	}
+	if msg := ErrorCodeMessage(req.raw.ErrorCode); msg != "" {
+		return msg
+	}
+	return req.raw.ErrorCode
+}
+
+// Datetime for the transaction processed. In UTC time.
+func (req *DepositCallbackRequest) StatementDate() (time.Time, error) {
+	return parseCallbackTime(req.raw.StatementDate, time.UTC)
+}
+
+// Datetime submitted during the transfer transaction, 按网关时区解析
+func (req *DepositCallbackRequest) SubmittedAt() (time.Time, error) {
+	tz, err := gatewayLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseCallbackTime(req.raw.Datetime, tz)
+}
+
+var callbackTimeLayouts = []string{
+	"2006-01-02 03:04:05PM",
+	"2006-01-02 03:04:05 PM",
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	"1/2/2006 3:04:05 PM",
+}
+
+func parseCallbackTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range callbackTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid datetime: %s", value)
+}
+
 func (req *DepositCallbackRequest) VerifySignature(conf *Config) error {
 	if conf == nil {
 		return fmt.Errorf("config is nil")
@@ -2,7 +2,6 @@ package help2pay
 
 import (
 	"context"
-	"errors"
 	"net/url"
 	"time"
 
@@ -41,6 +40,8 @@ type Config struct {
 
 	SuccessURL  string
 	CallbackURL string
+	// 无法从请求中确定语言时使用, 默认为 en-us
+	DefaultLanguage LanguageCode
 
 	tz *time.Location
 }
@@ -50,8 +51,13 @@ type Client struct {
 	conf *Config
 }
 
+// 网关使用的固定时区
+func gatewayLocation() (*time.Location, error) {
+	return time.LoadLocation("Asia/Chongqing")
+}
+
 func NewClient(env Env, conf Config) (*Client, error) {
-	tz, err := time.LoadLocation("Asia/Chongqing")
+	tz, err := gatewayLocation()
 	if err != nil {
 		return nil, err
 	}
@@ -81,23 +87,36 @@ type DepositFormRequest struct {
 	CustomerID string
 	CustomerIP string
 	Language   language.Tag
+	// Language 未设置时, 从用户浏览器的 Accept-Language 请求头中选择语言
+	AcceptLanguage string
+
+	// Column provided to merchant for Note usage. 0~500
+	Note string
+	// 为空时分别使用 Config.SuccessURL 与 Config.CallbackURL
+	FrontURI string
+	BackURI  string
 }
 
 func (req *DepositFormRequest) Validate() error {
 	if req.MerchantOrerID == "" {
 		return ErrInvalidMerchantOrderID
 	}
-	if !IsCurrencySupportBank(req.Currency, req.Bank) {
-		return errors.New("invalid bank or currency")
-	}
-
 	if req.Amount.LessThanOrEqual(decimal.Zero) {
 		return ErrInvalidAmount
 	}
+	if IsCryptoBank(req.Bank) {
+		// 文档未给出 TET-ETHE/TEX-TRON 对应的 Currency 取值, 仅按 String 3 校验, 取值需与 Help2Pay 确认
+		if len(req.Currency) != 3 {
+			return ErrInvalidCurrency
+		}
+	} else if err := checkDepositBank(req.Currency, req.Bank, req.Amount); err != nil {
+		return err
+	}
 	tr := req.Amount.Truncate(0)
-	if req.Currency == CurrencyCodeVND || req.Currency == CurrencyCodeIDR {
+	if req.Currency == CurrencyCodeVND || req.Currency == CurrencyCodeIDR || IsCryptoBank(req.Bank) {
 		// VND, IDR currency and PPTP (THB currency) Will
 		// Only Allow .00 decimal submission
+		// Cryptocurrency Will Only Allow .00 decimal submission.
 		if !tr.Equal(req.Amount) {
 			return ErrInvalidAmount
 		}
@@ -108,24 +127,51 @@ func (req *DepositFormRequest) Validate() error {
 	if req.CustomerIP == "" {
 		return ErrInvalidCustomerIP
 	}
+	if len(req.Note) > 500 {
+		return ErrInvalidNote
+	}
+	if len(req.FrontURI) > 500 {
+		return ErrInvalidFrontURI
+	}
+	if len(req.BackURI) > 500 {
+		return ErrInvalidBackURI
+	}
 	return nil
 }
 
+func (req *DepositFormRequest) languageCode(conf *Config) LanguageCode {
+	fallback := conf.DefaultLanguage
+	if fallback == "" {
+		fallback = LanguageCode_EN
+	}
+	if req.Language != language.Und {
+		return getLanguageCode(fallback, req.Language)
+	}
+	return getLanguageCode(fallback, parseAcceptLanguage(req.AcceptLanguage)...)
+}
+
 func (req *DepositFormRequest) toRaw(conf *Config) *rawDepositFormRequest {
 	raw := &rawDepositFormRequest{
 		Merchant:    conf.MerchantCode,
 		Currency:    req.Currency,
 		Customer:    req.CustomerID,
 		Reference:   req.MerchantOrerID,
-		Amount:      req.Amount.StringFixedBank(2),
+		Amount:      req.Amount.StringFixedBank(getAmountDecimals(req.Bank)),
 		Datetime:    req.formatDatetime(conf.tz),
+		Note:        req.Note,
 		FrontURI:    conf.SuccessURL,
 		BackURI:     conf.CallbackURL,
 		Bank:        req.Bank,
-		Language:    getLanguageCode(req.Language),
+		Language:    req.languageCode(conf),
 		ClientIP:    req.CustomerIP,
 		CompanyName: conf.CompanyName,
 	}
+	if req.FrontURI != "" {
+		raw.FrontURI = req.FrontURI
+	}
+	if req.BackURI != "" {
+		raw.BackURI = req.BackURI
+	}
 	raw.Key = signer{}.SignRequest(raw, conf.SecurityCode)
 	return raw
 }
@@ -153,3 +199,11 @@ func (cli *Client) MakeFiatDepositForm(_ context.Context, req *DepositFormReques
 		Fields: raw.Encode(),
 	}, nil
 }
+
+// MakeCryptoDepositForm 与 MakeFiatDepositForm 相同, 但仅接受 TET-ETHE/TEX-TRON
+func (cli *Client) MakeCryptoDepositForm(ctx context.Context, req *DepositFormRequest) (*DepositForm, error) {
+	if !IsCryptoBank(req.Bank) {
+		return nil, ErrUnsupportedBank
+	}
+	return cli.MakeFiatDepositForm(ctx, req)
+}
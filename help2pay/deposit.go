@@ -37,17 +37,33 @@ var (
 		language.Vietnamese,
 		language.Indonesian,
 		language.Burmese,
-		language.Filipino, // MAYBUG
+		language.Filipino, // tl 也会匹配到 fil
 		language.Hindi,
 		language.Khmer,
 	})
 )
 
-func getLanguageCode(lang language.Tag) LanguageCode {
-	_, i, _ := langMatcher.Match(lang)
+// 匹配置信度低于 language.Low 时返回 fallback, 例如 fr 不会被当作 en 处理
+func getLanguageCode(fallback LanguageCode, langs ...language.Tag) LanguageCode {
+	if len(langs) == 0 {
+		return fallback
+	}
+	_, i, conf := langMatcher.Match(langs...)
+	if conf < language.Low {
+		return fallback
+	}
 	return languageCodes[i]
 }
 
+// 解析 Accept-Language, 例如 "vi-VN,vi;q=0.9,en;q=0.8"
+func parseAcceptLanguage(header string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
 type CurrencyCode = string
 
 const (
@@ -9,4 +9,7 @@ var (
 	ErrInvalidCurrency        = errors.New("invalid currency")
 	ErrInvalidCustomerID      = errors.New("invalid customer id")
 	ErrInvalidCustomerIP      = errors.New("invalid customer ip")
+	ErrInvalidNote            = errors.New("invalid note")
+	ErrInvalidFrontURI        = errors.New("invalid front uri")
+	ErrInvalidBackURI         = errors.New("invalid back uri")
 )
@@ -90,8 +90,34 @@ var (
 	ErrorAccountStatusError = errors.New("account status error")
 	ErrorSystemError        = errors.New("system error")
 	ErrorTradeCanceled      = errors.New("trade canceled")
+	ErrorNoOrder            = errors.New("no order")
 )
 
+func statusCodeError(code IFPStatusCode, message string) error {
+	switch code {
+	case IFPStatusCode_TimestampError:
+		return ErrorTimestampError
+	case IFPStatusCode_SignatureError:
+		return ErrorSignatureError
+	case IFPStatusCode_AccesskeyError:
+		return ErrorAccesskeyError
+	case IFPStatusCode_ParameterError:
+		return ErrorParameterError
+	case IFPStatusCode_NoAdvertisement:
+		return ErrorNoAdvertisement
+	case IFPStatusCode_AccountStatusError:
+		return ErrorAccountStatusError
+	case IFPStatusCode_SystemError:
+		return ErrorSystemError
+	case IFPStatusCode_TradeCanceled:
+		return ErrorTradeCanceled
+	case IFPStatusCode_NoOrder:
+		return ErrorNoOrder
+	default:
+		return fmt.Errorf("unknown error: %s", message)
+	}
+}
+
 type CurrencyCode = string
 
 const (
@@ -154,6 +180,10 @@ type BuyCoinReply struct {
 	RedirectURL string
 	// 此次交易所匹配的广告编码
 	AdvertisementCode string
+	// 币安智能链(BSC)的收款地址
+	BSCAddress string
+	// Tron链的收款地址
+	TronAddress string
 }
 
 func (BuyCoinReply) fromRaw(raw *rawBuyResponse) (*BuyCoinReply, error) {
@@ -161,30 +191,13 @@ func (BuyCoinReply) fromRaw(raw *rawBuyResponse) (*BuyCoinReply, error) {
 		return nil, fmt.Errorf("raw response is nil")
 	}
 	if !raw.IsSuccess() {
-		switch raw.StatusCode {
-		case IFPStatusCode_TimestampError:
-			return nil, ErrorTimestampError
-		case IFPStatusCode_SignatureError:
-			return nil, ErrorSignatureError
-		case IFPStatusCode_AccesskeyError:
-			return nil, ErrorAccesskeyError
-		case IFPStatusCode_ParameterError:
-			return nil, ErrorParameterError
-		case IFPStatusCode_NoAdvertisement:
-			return nil, ErrorNoAdvertisement
-		case IFPStatusCode_AccountStatusError:
-			return nil, ErrorAccountStatusError
-		case IFPStatusCode_SystemError:
-			return nil, ErrorSystemError
-		case IFPStatusCode_TradeCanceled:
-			return nil, ErrorTradeCanceled
-		default:
-			return nil, fmt.Errorf("unknown error: %s", raw.Message)
-		}
+		return nil, statusCodeError(raw.StatusCode, raw.Message)
 	}
 	return &BuyCoinReply{
 		RedirectURL:       raw.Data.RedirectURL,
 		AdvertisementCode: raw.Data.AdvertisementCode,
+		BSCAddress:        raw.Data.ETH,
+		TronAddress:       raw.Data.TRX,
 	}, nil
 }
 
@@ -215,6 +228,48 @@ func (req *FiatBuyRequest) Validate() error {
 	return nil
 }
 
+type CoinBuyRequest struct {
+	Language language.Tag
+
+	MerchantOrderID string
+	// 买入的USDD数量, 最少 50
+	Quantity decimal.Decimal
+	// 所需支付币种
+	Currency CurrencyCode
+	// 实际支付人姓名, kyc need
+	UserName string
+}
+
+func (req *CoinBuyRequest) toRaw(conf *Config) *rawBuyRequest {
+	return &rawBuyRequest{
+		baseRequest: newBaseRequest(),
+		Mode:        BuyCoinMode_USDD,
+		Amount:      req.Quantity,
+		Ticket:      req.MerchantOrderID,
+		CallbackURL: conf.CallbackURL,
+		Language:    getLanguageCode(req.Language),
+		Currency:    req.Currency,
+		UserName:    req.UserName,
+	}
+}
+
+func (req *CoinBuyRequest) Validate() error {
+	if req.MerchantOrderID == "" {
+		return fmt.Errorf("merchant order id is empty")
+	}
+	if req.UserName == "" {
+		return fmt.Errorf("user name is empty")
+	}
+	if req.Currency == "" {
+		return fmt.Errorf("currency is empty")
+	}
+
+	if req.Quantity.LessThan(minBuyUSDDAmountD) {
+		return fmt.Errorf("%w: quantity must be at least %d", ErrInvalidAmount, minBuyUSDDAmount)
+	}
+	return nil
+}
+
 func (r *rawBuyRequest) isBuyUSDDMode() bool {
 	return r.Mode == BuyCoinMode_USDD || r.Currency == CurrencyCode_USDT
 }
@@ -77,7 +77,18 @@ func (cli *Client) BuyWithAmount(ctx context.Context, req *FiatBuyRequest) (*Buy
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
-	raw := req.toRaw(cli.config)
+	return cli.buy(ctx, req.toRaw(cli.config))
+}
+
+// 买入指定数量
+func (cli *Client) BuyWithQuantity(ctx context.Context, req *CoinBuyRequest) (*BuyCoinReply, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return cli.buy(ctx, req.toRaw(cli.config))
+}
+
+func (cli *Client) buy(ctx context.Context, raw *rawBuyRequest) (*BuyCoinReply, error) {
 	reqBody, err := raw.GenerateSignedRequest(ctx, cli.config)
 	if err != nil {
 		return nil, err
@@ -97,11 +108,6 @@ func (cli *Client) BuyWithAmount(ctx context.Context, req *FiatBuyRequest) (*Buy
 	return BuyCoinReply{}.fromRaw(res)
 }
 
-// 买入指定数量
-func (cli *Client) BuyWithQuantity(ctx context.Context, req any) (*BuyCoinReply, error) {
-	panic("not implemented")
-}
-
 func (cli *Client) QueryOrder(ctx context.Context, req *QueryOrderRequest) (*QueryOrderResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
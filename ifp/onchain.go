@@ -0,0 +1,186 @@
+package ifp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+)
+
+// 链上直充: 用户已持有 USDT 时, 以 USDT 作为支付币种下单, 直接向返回的链上地址转账, 不经过 P2P 广告
+type Chain = string
+
+const (
+	// 币安智能链, 对应返回中的 eth 字段
+	Chain_BSC Chain = "BSC"
+	// 波场, 对应返回中的 trx 字段
+	Chain_TRON Chain = "TRON"
+)
+
+var (
+	ErrInvalidChain   = errors.New("invalid chain")
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+var (
+	bscAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+	base58Alphabet = []byte("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+)
+
+// 校验链上地址格式
+// BSC 为 0x 开头的 40 位十六进制; TRON 为 T 开头的 base58check 地址, 解码后为 0x41 前缀的 21 字节
+func ValidateAddress(chain Chain, address string) error {
+	switch chain {
+	case Chain_BSC:
+		if !bscAddressRegex.MatchString(address) {
+			return fmt.Errorf("%w: %s address %q", ErrInvalidAddress, chain, address)
+		}
+		return nil
+	case Chain_TRON:
+		if err := validateTronAddress(address); err != nil {
+			return fmt.Errorf("%w: %s address %q: %s", ErrInvalidAddress, chain, address, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidChain, chain)
+	}
+}
+
+func validateTronAddress(address string) error {
+	const (
+		AddressLength = 34
+		Prefix        = 0x41
+		PayloadLength = 21
+		ChecksumLen   = 4
+	)
+	if len(address) != AddressLength || address[0] != 'T' {
+		return errors.New("must be 34 characters starting with T")
+	}
+	decoded, err := base58Decode(address)
+	if err != nil {
+		return err
+	}
+	if len(decoded) != PayloadLength+ChecksumLen || decoded[0] != Prefix {
+		return errors.New("invalid payload")
+	}
+	payload, checksum := decoded[:PayloadLength], decoded[PayloadLength:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:ChecksumLen], checksum) {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+func base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		idx := bytes.IndexByte(base58Alphabet, s[i])
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	decoded := n.Bytes()
+	// 前导 '1' 对应前导零字节
+	for i := 0; i < len(s) && s[i] == base58Alphabet[0]; i++ {
+		decoded = append([]byte{0}, decoded...)
+	}
+	return decoded, nil
+}
+
+type OnChainBuyRequest struct {
+	Language language.Tag
+
+	MerchantOrderID string
+	// 买入的USDD数量, 最少 50, 即用户需要转入的 USDT 数量
+	Quantity decimal.Decimal
+	// 用户转账使用的链
+	Chain Chain
+	// 实际支付人姓名, kyc need
+	UserName string
+}
+
+func (req *OnChainBuyRequest) toRaw(conf *Config) *rawBuyRequest {
+	return &rawBuyRequest{
+		baseRequest: newBaseRequest(),
+		Mode:        BuyCoinMode_USDD,
+		Amount:      req.Quantity,
+		Ticket:      req.MerchantOrderID,
+		CallbackURL: conf.CallbackURL,
+		Language:    getLanguageCode(req.Language),
+		Currency:    CurrencyCode_USDT,
+		UserName:    req.UserName,
+	}
+}
+
+func (req *OnChainBuyRequest) Validate() error {
+	if req.MerchantOrderID == "" {
+		return fmt.Errorf("merchant order id is empty")
+	}
+	if req.UserName == "" {
+		return fmt.Errorf("user name is empty")
+	}
+	if req.Chain != Chain_BSC && req.Chain != Chain_TRON {
+		return fmt.Errorf("%w: %s", ErrInvalidChain, req.Chain)
+	}
+	if req.Quantity.LessThan(minBuyUSDDAmountD) {
+		return fmt.Errorf("%w: quantity must be at least %d", ErrInvalidAmount, minBuyUSDDAmount)
+	}
+	return nil
+}
+
+type OnChainBuyReply struct {
+	*BuyCoinReply
+	// 订单已创建, 地址校验失败时也可用于对账或取消
+	MerchantOrderID string
+	Chain           Chain
+	// 用户需要转入 USDT 的地址
+	Address string
+	// 用户需要转入的 USDT 数量
+	Quantity decimal.Decimal
+}
+
+// 订单此时已创建, 地址校验失败时仍返回 reply (Address 为空), 避免丢失订单信息
+func (OnChainBuyReply) fromBuyReply(req *OnChainBuyRequest, reply *BuyCoinReply) (*OnChainBuyReply, error) {
+	out := &OnChainBuyReply{
+		BuyCoinReply:    reply,
+		MerchantOrderID: req.MerchantOrderID,
+		Chain:           req.Chain,
+		Quantity:        req.Quantity,
+	}
+	address := reply.BSCAddress
+	if req.Chain == Chain_TRON {
+		address = reply.TronAddress
+	}
+	if address == "" {
+		return out, fmt.Errorf("%w: no %s address returned", ErrInvalidAddress, req.Chain)
+	}
+	if err := ValidateAddress(req.Chain, address); err != nil {
+		return out, err
+	}
+	out.Address = address
+	return out, nil
+}
+
+// 链上直充, 返回对应链的收款地址
+// 订单创建后地址校验失败时, 同时返回 reply 与 ErrInvalidAddress, 调用方不应向用户展示地址
+func (cli *Client) BuyOnChain(ctx context.Context, req *OnChainBuyRequest) (*OnChainBuyReply, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	reply, err := cli.buy(ctx, req.toRaw(cli.config))
+	if err != nil {
+		return nil, err
+	}
+	return OnChainBuyReply{}.fromBuyReply(req, reply)
+}
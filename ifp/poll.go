@@ -0,0 +1,118 @@
+package ifp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPollInterval    = 5 * time.Second
+	defaultPollMaxInterval = time.Minute
+	// 超时取消(状态6)由 IFP 异步处理, 到期后多留一段时间等待状态落定
+	defaultPollGrace = 2 * time.Minute
+)
+
+type PollOrderOptions struct {
+	// 首次重试间隔, 默认 5s
+	Interval time.Duration
+	// 最大重试间隔, 默认 1m
+	MaxInterval time.Duration
+	// BuyTimeout 之后额外等待的时间, 默认 2m
+	Grace time.Duration
+}
+
+func (opts *PollOrderOptions) withDefaults() PollOrderOptions {
+	var o PollOrderOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultPollInterval
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = max(defaultPollMaxInterval, o.Interval)
+	}
+	if o.Grace <= 0 {
+		o.Grace = defaultPollGrace
+	}
+	return o
+}
+
+type PollOrderResult struct {
+	Order OrderInfo
+	// 轮询结束时订单仍未进入终态
+	TimedOut bool
+	// 查询次数
+	Attempts int
+}
+
+func (res *PollOrderResult) Status() OrderStatus {
+	return res.Order.Status
+}
+
+func (res *PollOrderResult) IsPaid() bool {
+	return IsPaidOrderStatus(res.Order.Status)
+}
+
+// 轮询订单直至进入终态, 或者自订单创建起超过 BuyTimeout(加上 Grace)
+// 网络错误会重试直至超时; IFP 返回的业务错误(如 NO_ORDER)会立即返回
+// 超时后若订单仍未进入终态, 返回 TimedOut 为 true 的结果, 例如用户已标记付款(fiat_transfered)但商户尚未确认
+func (cli *Client) PollOrder(ctx context.Context, req *QueryOrderRequest, opts *PollOrderOptions) (*PollOrderResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	o := opts.withDefaults()
+
+	var (
+		result   = &PollOrderResult{}
+		found    bool
+		lastErr  error
+		deadline = time.Now().Add(BuyTimeout + o.Grace)
+		interval = o.Interval
+	)
+	for {
+		result.Attempts++
+		res, err := cli.QueryOrder(ctx, req)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+		case !res.IsSuccess():
+			return nil, statusCodeError(res.StatusCode, res.Message)
+		default:
+			lastErr = nil
+			result.Order = res.Data
+			if !found {
+				found = true
+				if !res.Data.TransactionCreateTime.IsZero() {
+					deadline = res.Data.TransactionCreateTime.Add(BuyTimeout + o.Grace)
+				}
+			}
+			if IsTerminalOrderStatus(res.Data.Status) {
+				return result, nil
+			}
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			if !found {
+				return nil, fmt.Errorf("poll order timeout: %w", lastErr)
+			}
+			result.TimedOut = true
+			return result, nil
+		}
+		wait = min(wait, interval)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval = min(interval*2, o.MaxInterval)
+	}
+}
@@ -27,6 +27,27 @@ const (
 	OrderStatus_TimeoutCanceled
 )
 
+// 终态, 之后不会再发生变化
+func IsTerminalOrderStatus(s OrderStatus) bool {
+	switch s {
+	case OrderStatus_Confirmed, OrderStatus_Canceled, OrderStatus_ConfirmedByAdmin,
+		OrderStatus_DiscardedByAdmin, OrderStatus_TimeoutCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// 已确认收款(包括后台人工确认)
+func IsPaidOrderStatus(s OrderStatus) bool {
+	return s == OrderStatus_Confirmed || s == OrderStatus_ConfirmedByAdmin
+}
+
+// 已取消、被后台废弃或超时取消
+func IsCanceledOrderStatus(s OrderStatus) bool {
+	return s == OrderStatus_Canceled || s == OrderStatus_DiscardedByAdmin || s == OrderStatus_TimeoutCanceled
+}
+
 type OrderInfo struct {
 	CallbackURL           string          `json:"callbackUrl"`
 	ID                    string          `json:"code"`
@@ -130,6 +151,7 @@ func (req *QueryOrderRequest) Validate() error {
 
 func (qr *QueryOrderRequest) toRaw(_ *Config) *rawQueryOrderRequest {
 	return &rawQueryOrderRequest{
+		baseRequest:         *newBaseRequest(),
 		ExternalOrderNumber: qr.MerchantOrderID,
 	}
 }
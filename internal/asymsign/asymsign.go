@@ -0,0 +1,79 @@
+// package asymsign signs and verifies messages with any crypto.Signer,
+// so private keys can live outside the process (HSM, KMS, key custody service).
+package asymsign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// Sign hashes msg with hash and signs the digest.
+// RSA keys produce PKCS#1 v1.5 signatures, ECDSA keys ASN.1 DER signatures.
+// Ed25519 keys sign msg directly and ignore hash.
+func Sign(signer crypto.Signer, hash crypto.Hash, msg []byte) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("signer is nil")
+	}
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	}
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash %s is not available", hash)
+	}
+	h := hash.New()
+	h.Write(msg)
+	return signer.Sign(rand.Reader, h.Sum(nil), hash)
+}
+
+func Verify(pub crypto.PublicKey, hash crypto.Hash, msg []byte, signature []byte) error {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, msg, signature) {
+			return errors.New("ed25519: verification error")
+		}
+		return nil
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+
+	if !hash.Available() {
+		return fmt.Errorf("hash %s is not available", hash)
+	}
+	h := hash.New()
+	h.Write(msg)
+	digest := h.Sum(nil)
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("ecdsa: verification error")
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+}
+
+// RSAPublicKey returns the RSA public key of signer, for providers that only accept RSA signatures.
+func RSAPublicKey(signer crypto.Signer) (*rsa.PublicKey, error) {
+	if signer == nil {
+		return nil, errors.New("signer is nil")
+	}
+	pub, ok := signer.Public().(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: expect RSA, got %T", ErrUnsupportedKey, signer.Public())
+	}
+	return pub, nil
+}
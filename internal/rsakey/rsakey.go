@@ -0,0 +1,174 @@
+// package rsakey loads RSA keys from PEM blocks or base64 encoded DER,
+// in either PKCS#1 or PKCS#8/PKIX form.
+package rsakey
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Format is the detected encoding of a key, used in error messages.
+type Format = string
+
+const (
+	FormatPEMPKCS1       Format = "PEM PKCS#1"
+	FormatPEMPKCS8       Format = "PEM PKCS#8"
+	FormatPEMPKIX        Format = "PEM PKIX"
+	FormatPEMCertificate Format = "PEM certificate"
+	FormatDER            Format = "base64 DER"
+)
+
+var ErrNotRSAKey = errors.New("not an RSA key")
+
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty private key")
+	}
+
+	if block, _ := pem.Decode(data); block != nil {
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s private key: %w", FormatPEMPKCS1, err)
+			}
+			return key, nil
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s private key: %w", FormatPEMPKCS8, err)
+			}
+			rsaKey, ok := key.(*rsa.PrivateKey)
+			if !ok {
+				return nil, fmt.Errorf("%s private key is %T: %w", FormatPEMPKCS8, key, ErrNotRSAKey)
+			}
+			return rsaKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %q for private key", block.Type)
+		}
+	}
+
+	der, err := decodeBase64(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s private key: %w", FormatDER, err)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s PKCS#8 private key is %T: %w", FormatDER, key, ErrNotRSAKey)
+		}
+		return rsaKey, nil
+	}
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s private key as PKCS#8 or PKCS#1: %w", FormatDER, err)
+	}
+	return key, nil
+}
+
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty public key")
+	}
+
+	if block, _ := pem.Decode(data); block != nil {
+		switch block.Type {
+		case "RSA PUBLIC KEY":
+			key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s public key: %w", FormatPEMPKCS1, err)
+			}
+			return key, nil
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s public key: %w", FormatPEMPKIX, err)
+			}
+			rsaKey, ok := key.(*rsa.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("%s public key is %T: %w", FormatPEMPKIX, key, ErrNotRSAKey)
+			}
+			return rsaKey, nil
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", FormatPEMCertificate, err)
+			}
+			rsaKey, ok := cert.PublicKey.(*rsa.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("%s public key is %T: %w", FormatPEMCertificate, cert.PublicKey, ErrNotRSAKey)
+			}
+			return rsaKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %q for public key", block.Type)
+		}
+	}
+
+	der, err := decodeBase64(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s public key: %w", FormatDER, err)
+	}
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%s PKIX public key is %T: %w", FormatDER, key, ErrNotRSAKey)
+		}
+		return rsaKey, nil
+	}
+	key, err := x509.ParsePKCS1PublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s public key as PKIX or PKCS#1: %w", FormatDER, err)
+	}
+	return key, nil
+}
+
+func ReadPrivateKey(r io.Reader) (*rsa.PrivateKey, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	return ParsePrivateKey(data)
+}
+
+func ReadPublicKey(r io.Reader) (*rsa.PublicKey, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	return ParsePublicKey(data)
+}
+
+func LoadPrivateKeyFile(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	return ParsePrivateKey(data)
+}
+
+func LoadPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key file: %w", err)
+	}
+	return ParsePublicKey(data)
+}
+
+// decodeBase64 accepts base64 with line breaks and without padding.
+func decodeBase64(data []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(data)), "")
+	if der, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return der, nil
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
@@ -66,3 +66,29 @@ func (c *Client) CreatePayInURL(ctx context.Context, in *PayInRequest) (*PayInRe
 	}
 	return PayInResponse{}.fromRaw(&out)
 }
+
+func (c *Client) QueryPayIn(ctx context.Context, in *QueryPayInRequest) (*PayInDetail, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := in.toRaw(c.config).GenerateSignedRequest(c.config)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var out rawPaymentDetailResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return PayInDetail{}.fromRaw(&out)
+}
@@ -9,16 +9,39 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/decode-ex/payment-sdk/internal/strings2"
 )
 
-var ErrInvalidAmount = errors.New("invalid amount")
+var (
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrInvalidCustomerName = errors.New("invalid customer name")
+	ErrInvalidPayEEName    = errors.New("invalid payee name")
+	ErrInvalidExtraData    = errors.New("invalid extra data")
+)
+
+var (
+	partnerOrderCodeRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{3,40}$`)
+	payEENameRegex        = regexp.MustCompile(`^[a-zA-Z0-9 ]{0,32}$`)
+)
+
+const (
+	maxCustomerNameLength = 40
+	maxExtraDataLength    = 32
+)
+
+func newRandom() string {
+	randomBs := make([]byte, 16)
+	_, _ = rand.Read(randomBs)
+	return hex.EncodeToString(randomBs)
+}
 
 type rawPayInPayload struct {
 	//  Partner ID
@@ -59,20 +82,54 @@ type rawPayInPayload struct {
 type PayInRequest struct {
 	MerchantOrderID string
 	Amount          decimal.Decimal
+	// 付款人姓名, 可带越南语声调, 需包含空格, 最长 40 个字符
+	CustomerName string
+	// 页面展示的收款人名称, [a-zA-Z0-9 ]{0,32}, 非空时 CustomerName 无效
+	PayEEName string
+	// 原样在回调中返回, 最长 32 个字符
+	ExtraData string
+}
+
+func (payload *PayInRequest) Validate() error {
+	if !partnerOrderCodeRegex.MatchString(payload.MerchantOrderID) {
+		return fmt.Errorf("%w: %q", ErrInvalidMerchantOrderID, payload.MerchantOrderID)
+	}
+	if !payload.Amount.IsPositive() || !payload.Amount.IsInteger() {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, payload.Amount)
+	}
+	// 与 toRaw 一致, 按去除首尾空格后的值校验
+	// varchar 长度按字符计, 文档示例为带声调的越南语姓名
+	if name := strings.TrimSpace(payload.CustomerName); name != "" {
+		if utf8.RuneCountInString(name) > maxCustomerNameLength {
+			return fmt.Errorf("%w: longer than %d characters", ErrInvalidCustomerName, maxCustomerNameLength)
+		}
+		// error code 11: Customer name does not contain spaces
+		if !strings.Contains(name, " ") {
+			return fmt.Errorf("%w: must contain spaces", ErrInvalidCustomerName)
+		}
+	}
+	if !payEENameRegex.MatchString(payload.PayEEName) {
+		return fmt.Errorf("%w: %q", ErrInvalidPayEEName, payload.PayEEName)
+	}
+	if utf8.RuneCountInString(payload.ExtraData) > maxExtraDataLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidExtraData, maxExtraDataLength)
+	}
+	return nil
 }
 
 func (payload *PayInRequest) toRaw(cfg *Config) (*rawPayInPayload, error) {
-	if !payload.Amount.IsInteger() {
-		return nil, ErrInvalidAmount
+	if err := payload.Validate(); err != nil {
+		return nil, err
 	}
 	return &rawPayInPayload{
 		PartnerID:        cfg.PartnerID,
 		PartnerOrderCode: payload.MerchantOrderID,
 		Amount:           payload.Amount.StringFixed(0),
-		CustomerName:     "",
+		CustomerName:     strings.TrimSpace(payload.CustomerName),
+		PayEEName:        payload.PayEEName,
 		NotifyURL:        cfg.NotifyURL,
 		ReturnURL:        cfg.ReturnURL,
-		ExtraData:        "",
+		ExtraData:        payload.ExtraData,
 	}, nil
 }
 
@@ -106,9 +163,7 @@ func (raw *rawPayInPayload) GenerateSign(secret string) string {
 	const (
 		SignatureContent = "{partner_id}:{timestamp}:{random}:{partner_order_code}:{amount}:{customer_name}:{payee_name}:{notify_url}:{return_url}:{extra_data}:{partner_secret}"
 	)
-	randomBs := make([]byte, 16)
-	_, _ = rand.Read(randomBs)
-	randomStr := hex.EncodeToString(randomBs)
+	randomStr := newRandom()
 
 	ts := strconv.FormatInt(time.Now().Unix(), 10)
 
@@ -144,7 +199,7 @@ type rawPayInResponse struct {
 		SystemOrderCode  string          `json:"system_order_code"`  // Unique Payment ID from Long77
 		PartnerOrderCode string          `json:"partner_order_code"` // Unique Transaction ID from Partner's System
 		Amount           decimal.Decimal `json:"amount"`             // Amount of Payment
-		RequestTime      json.Number     `json:"request_time"`       // Request time
+		RequestTime      json.RawMessage `json:"request_time"`       // Request time
 		BankAccount      struct {
 			BankCode          string `json:"bank_code"`         // The Bank code
 			BankName          string `json:"bank_name"`         // The Bank name
@@ -155,20 +210,57 @@ type rawPayInResponse struct {
 		PaymentURL string `json:"payment_url"` // Payment URL from bank
 	} `json:"data"` // Return parameter set
 }
+
+// 虚拟账户对应的收款银行账户
+type BankAccount struct {
+	BankCode      string
+	BankName      string
+	AccountNumber string
+	AccountName   string
+}
+
 type PayInResponse struct {
 	SupplierOrderCode string
 	PaymentID         string
 	PaymentURL        string
+
+	Amount      decimal.Decimal
+	RequestTime time.Time
+	// 用户需要转账的收款账户
+	BankAccount BankAccount
 }
 
 func (PayInResponse) fromRaw(raw *rawPayInResponse) (*PayInResponse, error) {
 	if raw.Code != 200 {
 		return nil, fmt.Errorf("code: %d, message: %s", raw.Code, raw.Message)
 	}
+	// request_time 仅作参考, 格式不符时置零, 不影响已创建的订单
+	requestTime, _ := parseUnixTime(strings.Trim(string(raw.Data.RequestTime), `"`))
 	return &PayInResponse{
 		SupplierOrderCode: raw.Data.SystemOrderCode,
 		PaymentID:         raw.Data.PaymentID,
 		PaymentURL:        raw.Data.PaymentURL,
+		Amount:            raw.Data.Amount,
+		RequestTime:       requestTime,
+		BankAccount: BankAccount{
+			BankCode:      raw.Data.BankAccount.BankCode,
+			BankName:      raw.Data.BankAccount.BankName,
+			AccountNumber: raw.Data.BankAccount.BankAccountNumber,
+			AccountName:   raw.Data.BankAccount.BankAccountName,
+		},
+	}, nil
+}
+
+// 虚拟账户的 VietQR 转账二维码, 可嵌入自己的页面代替跳转 PaymentURL
+func (resp *PayInResponse) VietQR() (*VietQR, error) {
+	bin, ok := GetBankBIN(resp.BankAccount.BankCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBank, resp.BankAccount.BankCode)
+	}
+	return &VietQR{
+		BankBIN:       bin,
+		AccountNumber: resp.BankAccount.AccountNumber,
+		Amount:        resp.Amount,
 	}, nil
 }
 
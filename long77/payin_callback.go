@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -80,12 +81,14 @@ func (raw *rawPayInCallbackPayload) GenerateSign(secret string) string {
 }
 
 func (raw *rawPayInCallbackPayload) IsSuccess() bool {
-	return raw.Payment.Status == "4"
+	return PaymentStatus(raw.Payment.Status).IsSuccess()
 }
 
 type PayInCallbackRequest struct {
-	raw    *rawPayInCallbackPayload
-	amount decimal.Decimal
+	raw        *rawPayInCallbackPayload
+	amount     decimal.Decimal
+	paidAmount decimal.Decimal
+	fees       decimal.Decimal
 }
 
 func (payload *PayInCallbackRequest) UnmarshalJSON(data []byte) error {
@@ -97,9 +100,19 @@ func (payload *PayInCallbackRequest) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	paidAmount, err := parseOptionalDecimal(raw.Payment.PaidAmount)
+	if err != nil {
+		return fmt.Errorf("invalid paid_amount %q: %w", raw.Payment.PaidAmount, err)
+	}
+	fees, err := parseOptionalDecimal(raw.Payment.Fees.String())
+	if err != nil {
+		return fmt.Errorf("invalid fees %q: %w", raw.Payment.Fees, err)
+	}
 
 	payload.raw = raw
 	payload.amount = amount
+	payload.paidAmount = paidAmount
+	payload.fees = fees
 
 	return nil
 }
@@ -130,19 +143,70 @@ func (payload *PayInCallbackRequest) VerifySignature(conf *Config) error {
 	return payload.raw.VerifySignature(conf.Secret)
 }
 
+// 下单金额
 func (payload *PayInCallbackRequest) Amount() decimal.Decimal {
-	if payload.amount.IsZero() {
-		payload.amount, _ = decimal.NewFromString(payload.raw.Payment.PaidAmount)
-	}
 	return payload.amount
 }
 
+// 用户实际支付的金额, 可能与下单金额不一致
+func (payload *PayInCallbackRequest) PaidAmount() decimal.Decimal {
+	return payload.paidAmount
+}
+
+// 订单未到终态, 或超时且未付款时为 AmountNotPaid
+func (payload *PayInCallbackRequest) AmountDiscrepancy() AmountDiscrepancy {
+	return compareAmount(payload.Status(), payload.amount, payload.paidAmount)
+}
+
+// 订单手续费
+func (payload *PayInCallbackRequest) Fees() decimal.Decimal {
+	return payload.fees
+}
+
+func (payload *PayInCallbackRequest) ChannelCode() string {
+	return payload.raw.ChannelCode
+}
+
+// 银行侧的付款ID
+func (payload *PayInCallbackRequest) PaymentID() string {
+	return payload.raw.Payment.PaymentID
+}
+
+// 付款人账户, 银行未返回时为空
+func (payload *PayInCallbackRequest) PayerAccount() BankAccount {
+	return BankAccount{
+		BankCode:      payload.raw.Payment.BankCode,
+		AccountNumber: payload.raw.Payment.BankAccountNo,
+		AccountName:   payload.raw.Payment.BankAccountName,
+	}
+}
+
+func (payload *PayInCallbackRequest) RequestTime() time.Time {
+	t, _ := parseUnixTime(payload.raw.RequestTime.String())
+	return t
+}
+
+func (payload *PayInCallbackRequest) PaymentTime() time.Time {
+	t, _ := parseUnixTime(payload.raw.Payment.PaymentTime.String())
+	return t
+}
+
+func (payload *PayInCallbackRequest) CallbackTime() time.Time {
+	t, _ := parseUnixTime(payload.raw.Payment.CallbackTime.String())
+	return t
+}
+
 func (payload *PayInCallbackRequest) ClientCurrency() string {
 	return "VND"
 }
 
-func (payload *PayInCallbackRequest) Status() string {
-	return payload.raw.Payment.Status.String()
+// 下单时传入的 ExtraData
+func (payload *PayInCallbackRequest) ExtraData() string {
+	return payload.raw.ExtraData
+}
+
+func (payload *PayInCallbackRequest) Status() PaymentStatus {
+	return PaymentStatus(payload.raw.Payment.Status)
 }
 
 func ParsePayInCallbackRequest(req *http.Request) (*PayInCallbackRequest, error) {
@@ -0,0 +1,190 @@
+package long77
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/decode-ex/payment-sdk/internal/strings2"
+)
+
+var ErrInvalidMerchantOrderID = errors.New("invalid merchant order id")
+
+// Query limit: 6 times/min
+type rawPaymentDetailPayload struct {
+	//  Partner ID
+	// varchar(32)
+	PartnerID string `json:"partner_id"`
+	// Timestamp
+	// int(10)
+	Timestamp string `json:"timestamp"`
+	// Random characters
+	// string
+	Random string `json:"random"`
+	// Unique Transaction ID from Partner's System[A-Za-z0-9_-]{3,40}
+	// varchar(40)
+	PartnerOrderCode string `json:"partner_order_code"`
+	// md5(partner_id:timestamp:random:partner_order_code:partner_secret)
+	// varchar(32)
+	Sign string `json:"sign"`
+}
+
+type QueryPayInRequest struct {
+	MerchantOrderID string
+}
+
+func (req *QueryPayInRequest) Validate() error {
+	if !partnerOrderCodeRegex.MatchString(req.MerchantOrderID) {
+		return fmt.Errorf("%w: %q", ErrInvalidMerchantOrderID, req.MerchantOrderID)
+	}
+	return nil
+}
+
+func (req *QueryPayInRequest) toRaw(cfg *Config) *rawPaymentDetailPayload {
+	return &rawPaymentDetailPayload{
+		PartnerID:        cfg.PartnerID,
+		Timestamp:        strconv.FormatInt(time.Now().Unix(), 10),
+		Random:           newRandom(),
+		PartnerOrderCode: req.MerchantOrderID,
+	}
+}
+
+func (raw *rawPaymentDetailPayload) GenerateSign(secret string) string {
+	const (
+		SignatureContent = "{partner_id}:{timestamp}:{random}:{partner_order_code}:{partner_secret}"
+	)
+	formater := strings.NewReplacer(
+		"{partner_id}", raw.PartnerID,
+		"{timestamp}", raw.Timestamp,
+		"{random}", raw.Random,
+		"{partner_order_code}", raw.PartnerOrderCode,
+		"{partner_secret}", secret,
+	)
+	signature := formater.Replace(SignatureContent)
+	sign := md5.Sum(strings2.ToBytesNoAlloc(signature))
+	return hex.EncodeToString(sign[:])
+}
+
+func (raw *rawPaymentDetailPayload) GenerateSignedRequest(conf *Config) (*http.Request, error) {
+	const (
+		PATH   = "/gateway/bnb/paymentDetailsVA.do"
+		METHOD = http.MethodGet
+	)
+
+	raw.Sign = raw.GenerateSign(conf.Secret)
+
+	valus := url.Values{}
+	valus.Set("partner_id", raw.PartnerID)
+	valus.Set("timestamp", raw.Timestamp)
+	valus.Set("random", raw.Random)
+	valus.Set("partner_order_code", raw.PartnerOrderCode)
+	valus.Set("sign", raw.Sign)
+
+	path := PATH + "?" + valus.Encode()
+
+	return http.NewRequest(METHOD, path, nil)
+}
+
+type PayInDetail struct {
+	MerchantOrderID   string
+	SupplierOrderCode string
+	Amount            decimal.Decimal
+	RequestTime       time.Time
+	ExtraData         string
+
+	PaidAmount   decimal.Decimal
+	Fees         decimal.Decimal
+	PaymentTime  time.Time
+	CallbackTime time.Time
+	PaymentID    string
+	PaymentURL   string
+	Status       PaymentStatus
+}
+
+func (PayInDetail) fromRaw(raw *rawPaymentDetailResponse) (*PayInDetail, error) {
+	if raw.Code != 200 {
+		return nil, fmt.Errorf("code: %d, message: %s", raw.Code, raw.Msg)
+	}
+
+	data := &raw.Data
+	detail := &PayInDetail{
+		MerchantOrderID:   data.PartnerOrderCode,
+		SupplierOrderCode: data.SystemOrderCode,
+		ExtraData:         data.ExtraData,
+		PaymentID:         data.Payment.PaymentID,
+		PaymentURL:        data.Payment.PaymentURL,
+		Status:            PaymentStatus(data.Payment.Status),
+	}
+
+	decimals := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"amount", data.Amount, &detail.Amount},
+		{"paid_amount", data.Payment.PaidAmount, &detail.PaidAmount},
+		{"fees", data.Payment.Fees, &detail.Fees},
+	}
+	for _, d := range decimals {
+		v, err := parseOptionalDecimal(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", d.name, d.value, err)
+		}
+		*d.dst = v
+	}
+
+	times := []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"request_time", data.RequestTime, &detail.RequestTime},
+		{"payment_time", data.Payment.PaymentTime, &detail.PaymentTime},
+		{"callback_time", data.Payment.CallbackTime, &detail.CallbackTime},
+	}
+	for _, t := range times {
+		v, err := parseUnixTime(t.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", t.name, t.value, err)
+		}
+		*t.dst = v
+	}
+
+	return detail, nil
+}
+
+func (detail *PayInDetail) IsSuccess() bool {
+	return detail.Status.IsSuccess()
+}
+
+// 订单未到终态, 或超时且未付款时为 AmountNotPaid
+func (detail *PayInDetail) AmountDiscrepancy() AmountDiscrepancy {
+	return compareAmount(detail.Status, detail.Amount, detail.PaidAmount)
+}
+
+func parseOptionalDecimal(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(s)
+}
+
+// 秒级时间戳, 空值或 0 表示尚未发生
+func parseUnixTime(s string) (time.Time, error) {
+	if s == "" || s == "0" {
+		return time.Time{}, nil
+	}
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(ts, 0), nil
+}
@@ -0,0 +1,83 @@
+package long77
+
+import "github.com/shopspring/decimal"
+
+// 代收订单状态, 文档只定义了以下三种
+type PaymentStatus string
+
+const (
+	PaymentStatusPending PaymentStatus = "2"
+	PaymentStatusTimeout PaymentStatus = "3"
+	PaymentStatusSuccess PaymentStatus = "4"
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PaymentStatusPending:
+		return "PENDING"
+	case PaymentStatusTimeout:
+		return "TIMEOUT"
+	case PaymentStatusSuccess:
+		return "SUCCESS"
+	default:
+		return "UNKNOWN(" + string(s) + ")"
+	}
+}
+
+func (s PaymentStatus) IsKnown() bool {
+	switch s {
+	case PaymentStatusPending, PaymentStatusTimeout, PaymentStatusSuccess:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s PaymentStatus) IsSuccess() bool {
+	return s == PaymentStatusSuccess
+}
+
+// 终态, 之后不会再发生变化
+func (s PaymentStatus) IsTerminal() bool {
+	return s == PaymentStatusSuccess || s == PaymentStatusTimeout
+}
+
+// 实付金额与下单金额的比较结果
+type AmountDiscrepancy int
+
+const (
+	AmountExact AmountDiscrepancy = iota
+	AmountUnderpaid
+	AmountOverpaid
+	// 订单未到终态, 或已超时且未收到任何付款
+	AmountNotPaid
+)
+
+func (d AmountDiscrepancy) String() string {
+	switch d {
+	case AmountExact:
+		return "exact"
+	case AmountUnderpaid:
+		return "underpaid"
+	case AmountOverpaid:
+		return "overpaid"
+	case AmountNotPaid:
+		return "not paid"
+	default:
+		return "unknown"
+	}
+}
+
+func compareAmount(status PaymentStatus, requested, paid decimal.Decimal) AmountDiscrepancy {
+	if !status.IsTerminal() || (status == PaymentStatusTimeout && paid.IsZero()) {
+		return AmountNotPaid
+	}
+	switch paid.Cmp(requested) {
+	case -1:
+		return AmountUnderpaid
+	case 1:
+		return AmountOverpaid
+	default:
+		return AmountExact
+	}
+}
@@ -0,0 +1,211 @@
+package long77
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+var (
+	ErrUnknownBank        = errors.New("unknown bank")
+	ErrInvalidBankAccount = errors.New("invalid bank account")
+	ErrInvalidQRMessage   = errors.New("invalid qr message")
+)
+
+// NAPAS 银行 BIN, 以 Long77 返回的 bank_code 为键
+// BIN 为 NAPAS 公布的成员银行代码, 键取 VietQR 银行列表 (https://api.vietqr.io/v2/banks) 的 code 字段,
+// Long77 文档示例中的 VCCB 与该列表一致; 未收录的银行请直接设置 VietQR.BankBIN
+var bankBINs = map[string]string{
+	"ABB":      "970425", // ABBANK
+	"ACB":      "970416", // ACB
+	"BAB":      "970409", // Bac A Bank
+	"BIDV":     "970418", // BIDV
+	"BVB":      "970438", // BaoViet Bank
+	"CIMB":     "422589", // CIMB
+	"DOB":      "970406", // DongA Bank
+	"EIB":      "970431", // Eximbank
+	"GPB":      "970408", // GPBank
+	"HDB":      "970437", // HDBank
+	"HLBVN":    "970442", // Hong Leong Bank
+	"ICB":      "970415", // VietinBank
+	"IVB":      "970434", // Indovina Bank
+	"KLB":      "970452", // Kienlongbank
+	"LPB":      "970449", // LienVietPostBank
+	"MB":       "970422", // MBBank
+	"MSB":      "970426", // MSB
+	"NAB":      "970428", // Nam A Bank
+	"NCB":      "970419", // NCB
+	"OCB":      "970448", // OCB
+	"OJB":      "970414", // OceanBank
+	"PBVN":     "970439", // Public Bank Vietnam
+	"PGB":      "970430", // PGBank
+	"PVCB":     "970412", // PVcomBank
+	"SCB":      "970429", // SCB
+	"SEAB":     "970440", // SeABank
+	"SGICB":    "970400", // Saigonbank
+	"SHB":      "970443", // SHB
+	"SHBVN":    "970424", // Shinhan Bank
+	"STB":      "970403", // Sacombank
+	"TCB":      "970407", // Techcombank
+	"TPB":      "970423", // TPBank
+	"UOB":      "970458", // UOB
+	"VAB":      "970427", // VietABank
+	"VBA":      "970405", // Agribank
+	"VCB":      "970436", // Vietcombank
+	"VCCB":     "970454", // BVBank (Viet Capital Bank)
+	"VIB":      "970441", // VIB
+	"VIETBANK": "970433", // VietBank
+	"VPB":      "970432", // VPBank
+	"VRB":      "970421", // VRB
+	"WVN":      "970457", // Woori Bank
+}
+
+func GetBankBIN(bankCode string) (string, bool) {
+	bin, ok := bankBINs[strings.ToUpper(bankCode)]
+	return bin, ok
+}
+
+var (
+	bankBINRegex     = regexp.MustCompile(`^[0-9]{6}$`)
+	bankAccountRegex = regexp.MustCompile(`^[0-9A-Za-z]{1,19}$`)
+	// 银行 App 对非 ASCII 附言的兼容性不一, 只允许可打印 ASCII
+	qrMessageRegex = regexp.MustCompile(`^[\x20-\x7E]{0,99}$`)
+)
+
+// VietQR (NAPAS 247) 转账二维码
+type VietQR struct {
+	// 收款银行 BIN, 6 位数字
+	BankBIN       string
+	AccountNumber string
+	// 为零时生成不带金额的静态码
+	Amount decimal.Decimal
+	// 转账附言, 可选, 仅限可打印 ASCII, 最长 99 字节
+	Message string
+}
+
+func (qr *VietQR) Validate() error {
+	if !bankBINRegex.MatchString(qr.BankBIN) {
+		return fmt.Errorf("%w: bin %q", ErrUnknownBank, qr.BankBIN)
+	}
+	if !bankAccountRegex.MatchString(qr.AccountNumber) {
+		return fmt.Errorf("%w: %q", ErrInvalidBankAccount, qr.AccountNumber)
+	}
+	if qr.Amount.IsNegative() || !qr.Amount.IsInteger() {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, qr.Amount)
+	}
+	// EMVCo 单个字段最长 99 字节
+	if !qrMessageRegex.MatchString(qr.Message) {
+		return fmt.Errorf("%w: %q", ErrInvalidQRMessage, qr.Message)
+	}
+	return nil
+}
+
+// EMVCo 格式的二维码内容
+func (qr *VietQR) Payload() (string, error) {
+	const (
+		GUID          = "A000000727"
+		ServiceCode   = "QRIBFTTA" // 转账到账户
+		CurrencyVND   = "704"
+		CountryCode   = "VN"
+		StaticMethod  = "11"
+		DynamicMethod = "12"
+	)
+	if err := qr.Validate(); err != nil {
+		return "", err
+	}
+
+	beneficiary := emvField("00", qr.BankBIN) + emvField("01", qr.AccountNumber)
+	merchant := emvField("00", GUID) + emvField("01", beneficiary) + emvField("02", ServiceCode)
+
+	method := StaticMethod
+	if qr.Amount.IsPositive() {
+		method = DynamicMethod
+	}
+
+	var b strings.Builder
+	b.WriteString(emvField("00", "01"))
+	b.WriteString(emvField("01", method))
+	b.WriteString(emvField("38", merchant))
+	b.WriteString(emvField("53", CurrencyVND))
+	if qr.Amount.IsPositive() {
+		b.WriteString(emvField("54", qr.Amount.StringFixed(0)))
+	}
+	b.WriteString(emvField("58", CountryCode))
+	if qr.Message != "" {
+		b.WriteString(emvField("62", emvField("08", qr.Message)))
+	}
+	b.WriteString("6304")
+	b.WriteString(fmt.Sprintf("%04X", crc16CCITT([]byte(b.String()))))
+	return b.String(), nil
+}
+
+// PNG 图片, size 为边长像素
+func (qr *VietQR) PNG(size int) ([]byte, error) {
+	code, err := qr.encode()
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// SVG 图片, size 为边长, 单位与 viewBox 一致
+func (qr *VietQR) SVG(size int) ([]byte, error) {
+	code, err := qr.encode()
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+	n := len(bitmap)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			// 合并同一行连续的黑色模块
+			start := x
+			for x+1 < len(row) && row[x+1] {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start, y, x-start+1, x-start+1)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes(), nil
+}
+
+func (qr *VietQR) encode() (*qrcode.QRCode, error) {
+	payload, err := qr.Payload()
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.New(payload, qrcode.Medium)
+}
+
+func emvField(id, value string) string {
+	return id + fmt.Sprintf("%02d", len(value)) + value
+}
+
+// CRC-16/CCITT-FALSE, poly 0x1021, init 0xFFFF
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
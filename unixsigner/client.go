@@ -0,0 +1,151 @@
+package unixsigner
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	opPublic = "public"
+	opSign   = "sign"
+)
+
+type request struct {
+	Op     string `json:"op"`
+	Hash   string `json:"hash,omitempty"`
+	Digest []byte `json:"digest,omitempty"`
+}
+
+type response struct {
+	PublicKey []byte `json:"public_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// 支持的摘要算法, crypto.Hash(0) 表示对原文签名 (Ed25519)
+var hashes = map[string]crypto.Hash{
+	"":        crypto.Hash(0),
+	"SHA-1":   crypto.SHA1,
+	"SHA-256": crypto.SHA256,
+	"SHA-384": crypto.SHA384,
+	"SHA-512": crypto.SHA512,
+}
+
+func hashName(h crypto.Hash) (string, error) {
+	if h == 0 {
+		return "", nil
+	}
+	name := h.String()
+	if _, ok := hashes[name]; !ok {
+		return "", fmt.Errorf("unsupported hash %s", name)
+	}
+	return name, nil
+}
+
+// Signer implements crypto.Signer by forwarding digests to a signing process listening on a Unix socket.
+// Each Sign call opens a new connection, so a Signer is safe for concurrent use.
+type Signer struct {
+	path    string
+	timeout time.Duration
+
+	once   sync.Once
+	public crypto.PublicKey
+	err    error
+}
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Dial connects to the signing process at path and fetches its public key.
+func Dial(path string, timeout time.Duration) (*Signer, error) {
+	s := &Signer{
+		path:    path,
+		timeout: timeout,
+	}
+	if _, err := s.publicKey(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Signer) publicKey() (crypto.PublicKey, error) {
+	s.once.Do(func() {
+		resp, err := s.call(&request{Op: opPublic})
+		if err != nil {
+			s.err = fmt.Errorf("fetch public key: %w", err)
+			return
+		}
+		pub, err := x509.ParsePKIXPublicKey(resp.PublicKey)
+		if err != nil {
+			s.err = fmt.Errorf("parse public key: %w", err)
+			return
+		}
+		s.public = pub
+	})
+	return s.public, s.err
+}
+
+// Public returns the public key fetched by Dial.
+func (s *Signer) Public() crypto.PublicKey {
+	pub, _ := s.publicKey()
+	return pub
+}
+
+var ErrUnsupportedOpts = errors.New("unsupported signer opts")
+
+// Sign forwards digest to the signing process.
+// The protocol only carries the hash, so RSA keys always produce PKCS#1 v1.5 signatures;
+// *rsa.PSSOptions is rejected instead of being silently downgraded.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, fmt.Errorf("%w: PSS padding", ErrUnsupportedOpts)
+	}
+	name, err := hashName(opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.call(&request{
+		Op:     opSign,
+		Hash:   name,
+		Digest: digest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote sign: %w", err)
+	}
+	return resp.Signature, nil
+}
+
+func (s *Signer) call(req *request) (*response, error) {
+	conn, err := net.DialTimeout("unix", s.path, s.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := &response{}
+	if err := json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
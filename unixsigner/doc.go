@@ -0,0 +1,10 @@
+// package unixsigner provides a crypto.Signer that delegates signing to another
+// process over a Unix socket, and a local stand-in server for testing.
+//
+// Protocol: one JSON request per line, answered by one JSON response per line.
+//
+//	{"op":"public"}                              => {"public_key":"<base64 PKIX DER>"}
+//	{"op":"sign","hash":"SHA-256","digest":"…"}  => {"signature":"<base64>"}
+//
+// Failures are reported as {"error":"…"}.
+package unixsigner
@@ -0,0 +1,108 @@
+package unixsigner
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+)
+
+// Server is a local stand-in for an external key custody process.
+// It holds the private key in memory and must only be used for testing.
+type Server struct {
+	signer   crypto.Signer
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// Listen starts serving signer on a Unix socket at path, removing any stale socket file first.
+func Listen(path string, signer crypto.Signer) (*Server, error) {
+	if signer == nil {
+		return nil, errors.New("signer is nil")
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale socket: %w", err)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &Server{
+		signer:   signer,
+		listener: ln,
+	}
+	srv.wg.Add(1)
+	go srv.serve()
+	return srv, nil
+}
+
+func (srv *Server) Addr() string {
+	return srv.listener.Addr().String()
+}
+
+// Close stops accepting connections and waits for in-flight requests.
+func (srv *Server) Close() error {
+	err := srv.listener.Close()
+	srv.wg.Wait()
+	return err
+}
+
+func (srv *Server) serve() {
+	defer srv.wg.Done()
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		srv.wg.Add(1)
+		go func() {
+			defer srv.wg.Done()
+			defer conn.Close()
+			srv.handle(conn)
+		}()
+	}
+}
+
+func (srv *Server) handle(conn net.Conn) {
+	enc := json.NewEncoder(conn)
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return
+	}
+	req := &request{}
+	if err := json.Unmarshal(line, req); err != nil {
+		enc.Encode(&response{Error: fmt.Sprintf("decode request: %s", err)})
+		return
+	}
+	enc.Encode(srv.process(req))
+}
+
+func (srv *Server) process(req *request) *response {
+	switch req.Op {
+	case opPublic:
+		der, err := x509.MarshalPKIXPublicKey(srv.signer.Public())
+		if err != nil {
+			return &response{Error: err.Error()}
+		}
+		return &response{PublicKey: der}
+	case opSign:
+		hash, ok := hashes[req.Hash]
+		if !ok {
+			return &response{Error: fmt.Sprintf("unsupported hash %q", req.Hash)}
+		}
+		sig, err := srv.signer.Sign(rand.Reader, req.Digest, hash)
+		if err != nil {
+			return &response{Error: err.Error()}
+		}
+		return &response{Signature: sig}
+	default:
+		return &response{Error: fmt.Sprintf("unknown op %q", req.Op)}
+	}
+}